@@ -104,3 +104,8 @@ func TestMoveCursorDirectional(t *testing.T) {
 		})
 	}
 }
+
+func TestStripStyles(t *testing.T) {
+	input := Red.Colorize("red") + " " + Combine("bold", Bold, Blue) + MoveCursorUp(1) + ClearLine
+	require.Equal(t, "red bold"+MoveCursorUp(1)+ClearLine, StripStyles(input))
+}
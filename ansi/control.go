@@ -16,6 +16,11 @@ const (
 	RestoreCursor  = "\033[u"
 	HideCursor     = "\033[?25l"
 	ShowCursor     = "\033[?25h"
+
+	// Screen control
+	ClearToEndOfScreen = "\033[J"
+	EnterAltScreen     = "\033[?1049h"
+	ExitAltScreen      = "\033[?1049l"
 )
 
 // ClearScreenAndHome clears the screen and moves cursor to home position.
@@ -1,6 +1,9 @@
 package ansi
 
-import "fmt"
+import (
+	"fmt"
+	"regexp"
+)
 
 const (
 	StyleReset    Style = iota
@@ -13,6 +16,8 @@ const (
 	Strikethrough Style = iota
 )
 
+var sgrRegex = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+
 type (
 	// Style represents ANSI text formatting styles such as bold, italic, underline, etc.
 	// Styles can be applied individually or combined with colors using the Combine function.
@@ -142,3 +147,13 @@ func Combine(text string, styles ...any) string {
 
 	return fmt.Sprintf("%s%s%s", combined, text, StyleReset.String())
 }
+
+// StripStyles removes all color and style (SGR) escape sequences from text while leaving cursor
+// movement and other control sequences intact.
+//
+// Example:
+//
+//	plain := ansi.StripStyles(ansi.Red.Colorize("Error"))  // Returns "Error"
+func StripStyles(text string) string {
+	return sgrRegex.ReplaceAllString(text, "")
+}
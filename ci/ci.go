@@ -0,0 +1,213 @@
+// Package ci detects continuous integration providers and renders their log folding markers, so that
+// frames become collapsible groups in CI logs and animated components print start and finish lines
+// instead of redrawing.
+//
+// GitHub Actions, GitLab CI and Buildkite are detected out of the box. Other providers can be added
+// with Register, and detection can be turned off by setting None on a terminal environment.
+//
+// Example:
+//
+//	env := terminal.Default()
+//	env.CI = ci.None // render as on a local terminal, even in CI
+//	terminal.SetDefault(env)
+package ci
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pseudomuto/gooey/ansi"
+)
+
+var (
+	// GitHubActions folds top-level frames with ::group:: and ::endgroup:: workflow commands. GitHub
+	// doesn't support nested groups, so nested frames aren't folded.
+	GitHubActions Provider = githubActions{}
+
+	// GitLab folds every frame with collapsible section_start and section_end markers.
+	GitLab Provider = gitLab{}
+
+	// Buildkite folds top-level frames with --- group headers, expanding a frame's group with ^^^ +++
+	// when it fails. Buildkite groups have no end marker and don't nest.
+	Buildkite Provider = buildkite{}
+
+	// None is never detected and folds nothing. Setting it as a terminal environment's provider turns
+	// CI detection off, so output renders as it would on a local terminal.
+	None Provider = none{}
+
+	providers      = []Provider{GitHubActions, GitLab, Buildkite}
+	providersMutex sync.RWMutex
+
+	// sectionNameRegex matches the characters GitLab doesn't allow in section names
+	sectionNameRegex = regexp.MustCompile(`[^a-zA-Z0-9_.-]+`)
+
+	// workflowDataEscaper escapes the data of a GitHub Actions workflow command, so that it can't end
+	// the command early or start another one
+	workflowDataEscaper = strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A")
+)
+
+type (
+	// Provider detects a CI provider and renders the markers that fold a group of log lines.
+	//
+	// Example:
+	//
+	//	type teamCity struct{}
+	//
+	//	func (teamCity) Name() string { return "TeamCity" }
+	//
+	//	func (teamCity) Detect(getenv func(string) string) bool {
+	//		return getenv("TEAMCITY_VERSION") != ""
+	//	}
+	//
+	//	func (teamCity) StartGroup(g ci.Group) string {
+	//		return fmt.Sprintf("##teamcity[blockOpened name='%s']\n", g.Title)
+	//	}
+	//
+	//	func (teamCity) EndGroup(g ci.Group) string {
+	//		return fmt.Sprintf("##teamcity[blockClosed name='%s']\n", g.Title)
+	//	}
+	Provider interface {
+		// Name returns the provider's name, e.g. "GitHub Actions".
+		Name() string
+		// Detect reports whether the process is running on this provider, using getenv to read the
+		// environment.
+		Detect(getenv func(string) string) bool
+		// StartGroup returns the lines that start folding a group, including their trailing newline, or
+		// an empty string when the group can't be folded (e.g. nested groups on some providers).
+		StartGroup(g Group) string
+		// EndGroup returns the lines that end a group started with StartGroup, or an empty string.
+		EndGroup(g Group) string
+	}
+
+	// Group describes a group of log lines, such as the content of a frame.
+	Group struct {
+		// ID uniquely identifies the group within the process.
+		ID string
+		// Title is the group's title, without colour or style sequences.
+		Title string
+		// Depth is the 1-based nesting depth of the group; a top-level group has depth 1.
+		Depth int
+		// Time is when the group started or ended.
+		Time time.Time
+		// Failed reports whether the group ended with a failure. It's only set for EndGroup.
+		Failed bool
+	}
+
+	githubActions struct{}
+	gitLab        struct{}
+	buildkite     struct{}
+	none          struct{}
+)
+
+// Register adds a provider, which is checked before the ones registered earlier and the built-in ones.
+//
+// Example:
+//
+//	ci.Register(teamCity{})
+func Register(p Provider) {
+	providersMutex.Lock()
+	defer providersMutex.Unlock()
+	providers = append([]Provider{p}, providers...)
+}
+
+// Detect returns the first registered provider that detects itself from getenv, or nil when the process
+// isn't running in CI.
+//
+// Example:
+//
+//	if p := ci.Detect(os.Getenv); p != nil {
+//		fmt.Println("running on", p.Name())
+//	}
+func Detect(getenv func(string) string) Provider {
+	providersMutex.RLock()
+	defer providersMutex.RUnlock()
+
+	for _, p := range providers {
+		if p.Detect(getenv) {
+			return p
+		}
+	}
+
+	return nil
+}
+
+func (githubActions) Name() string { return "GitHub Actions" }
+
+func (githubActions) Detect(getenv func(string) string) bool {
+	return getenv("GITHUB_ACTIONS") == "true"
+}
+
+func (githubActions) StartGroup(g Group) string {
+	if g.Depth > 1 {
+		return ""
+	}
+
+	return "::group::" + workflowDataEscaper.Replace(g.Title) + "\n"
+}
+
+func (githubActions) EndGroup(g Group) string {
+	if g.Depth > 1 {
+		return ""
+	}
+
+	return "::endgroup::\n"
+}
+
+func (gitLab) Name() string { return "GitLab CI" }
+
+func (gitLab) Detect(getenv func(string) string) bool {
+	return getenv("GITLAB_CI") == "true"
+}
+
+func (gitLab) StartGroup(g Group) string {
+	return fmt.Sprintf("%ssection_start:%d:%s[collapsed=true]\r%s%s\n",
+		ansi.ClearLine, g.Time.Unix(), sectionName(g), ansi.ClearLine, g.Title)
+}
+
+func (gitLab) EndGroup(g Group) string {
+	return fmt.Sprintf("%ssection_end:%d:%s\r%s\n", ansi.ClearLine, g.Time.Unix(), sectionName(g), ansi.ClearLine)
+}
+
+func (buildkite) Name() string { return "Buildkite" }
+
+func (buildkite) Detect(getenv func(string) string) bool {
+	return getenv("BUILDKITE") == "true"
+}
+
+func (buildkite) StartGroup(g Group) string {
+	if g.Depth > 1 {
+		return ""
+	}
+
+	return "--- " + g.Title + "\n"
+}
+
+func (buildkite) EndGroup(g Group) string {
+	if g.Depth > 1 || !g.Failed {
+		return ""
+	}
+
+	return "^^^ +++\n"
+}
+
+func (none) Name() string { return "None" }
+
+func (none) Detect(func(string) string) bool { return false }
+
+func (none) StartGroup(Group) string { return "" }
+
+func (none) EndGroup(Group) string { return "" }
+
+// sectionName returns a GitLab section name for g, which must be unique and only contain letters,
+// digits, underscores, dots and hyphens
+func sectionName(g Group) string {
+	name := strings.Trim(sectionNameRegex.ReplaceAllString(strings.ToLower(g.Title), "_"), "_")
+	if name == "" {
+		return g.ID
+	}
+
+	return g.ID + "_" + name
+}
@@ -0,0 +1,107 @@
+package ci_test
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/pseudomuto/gooey/ci"
+	"github.com/stretchr/testify/require"
+)
+
+type teamCity struct{}
+
+func (teamCity) Name() string { return "TeamCity" }
+
+func (teamCity) Detect(getenv func(string) string) bool {
+	return getenv("TEAMCITY_VERSION") != ""
+}
+
+func (teamCity) StartGroup(g Group) string { return "##teamcity[blockOpened name='" + g.Title + "']\n" }
+
+func (teamCity) EndGroup(g Group) string { return "##teamcity[blockClosed name='" + g.Title + "']\n" }
+
+func env(vars map[string]string) func(string) string {
+	return func(key string) string { return vars[key] }
+}
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		name     string
+		vars     map[string]string
+		expected Provider
+	}{
+		{"local", map[string]string{}, nil},
+		{"github", map[string]string{"GITHUB_ACTIONS": "true"}, GitHubActions},
+		{"gitlab", map[string]string{"GITLAB_CI": "true"}, GitLab},
+		{"buildkite", map[string]string{"BUILDKITE": "true"}, Buildkite},
+	}
+
+	// None is never detected
+	require.False(t, None.Detect(env(map[string]string{"GITHUB_ACTIONS": "true"})))
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expected, Detect(env(test.vars)))
+		})
+	}
+}
+
+func TestRegister(t *testing.T) {
+	Register(teamCity{})
+
+	// Registered providers are checked before the built-in ones
+	p := Detect(env(map[string]string{"TEAMCITY_VERSION": "2024.1", "GITHUB_ACTIONS": "true"}))
+	require.Equal(t, "TeamCity", p.Name())
+	require.Equal(t, GitHubActions, Detect(env(map[string]string{"GITHUB_ACTIONS": "true"})))
+}
+
+func TestGroupMarkers(t *testing.T) {
+	start := time.Unix(1700000000, 0)
+	top := Group{ID: "gooey_1", Title: "Build & Test", Depth: 1, Time: start}
+	nested := Group{ID: "gooey_2", Title: "Lint", Depth: 2, Time: start}
+	failed := top
+	failed.Failed = true
+
+	tests := []struct {
+		name     string
+		provider Provider
+		group    Group
+		start    string
+		end      string
+	}{
+		{"github", GitHubActions, top, "::group::Build & Test\n", "::endgroup::\n"},
+		{"github nested", GitHubActions, nested, "", ""},
+		{
+			"github escaped",
+			GitHubActions,
+			Group{ID: "gooey_3", Title: "Deploy\r\n::error::100% broken", Depth: 1, Time: start},
+			"::group::Deploy%0D%0A::error::100%25 broken\n",
+			"::endgroup::\n",
+		},
+		{
+			"gitlab",
+			GitLab,
+			top,
+			"\x1b[Ksection_start:1700000000:gooey_1_build_test[collapsed=true]\r\x1b[KBuild & Test\n",
+			"\x1b[Ksection_end:1700000000:gooey_1_build_test\r\x1b[K\n",
+		},
+		{
+			"gitlab nested",
+			GitLab,
+			nested,
+			"\x1b[Ksection_start:1700000000:gooey_2_lint[collapsed=true]\r\x1b[KLint\n",
+			"\x1b[Ksection_end:1700000000:gooey_2_lint\r\x1b[K\n",
+		},
+		{"buildkite", Buildkite, top, "--- Build & Test\n", ""},
+		{"buildkite failed", Buildkite, failed, "--- Build & Test\n", "^^^ +++\n"},
+		{"buildkite nested", Buildkite, nested, "", ""},
+		{"none", None, top, "", ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.start, test.provider.StartGroup(test.group))
+			require.Equal(t, test.end, test.provider.EndGroup(test.group))
+		})
+	}
+}
@@ -0,0 +1,94 @@
+// Package clock provides a pluggable source of time for gooey components.
+// Frames, spinners and progress bars read the current time and drive their animations through a Clock,
+// which defaults to the system clock. Tests can substitute a Fake clock, either globally or per component,
+// and advance time manually to get byte-for-byte reproducible output.
+package clock
+
+import (
+	"sync"
+	"time"
+)
+
+var (
+	// Real is the Clock backed by the system time and time.Ticker.
+	Real Clock = realClock{}
+
+	defaultClock      = Real
+	defaultClockMutex sync.RWMutex
+)
+
+type (
+	// Clock is the source of time used by gooey components.
+	Clock interface {
+		// Now returns the current time.
+		Now() time.Time
+		// Since returns the time elapsed since t.
+		Since(t time.Time) time.Duration
+		// NewTicker returns a Ticker that ticks every d.
+		NewTicker(d time.Duration) Ticker
+	}
+
+	// Ticker delivers ticks at regular intervals, mirroring time.Ticker.
+	Ticker interface {
+		// C returns the channel on which ticks are delivered.
+		C() <-chan time.Time
+		// Stop turns off the ticker. No more ticks will be delivered after Stop returns.
+		Stop()
+	}
+
+	realClock  struct{}
+	realTicker struct{ *time.Ticker }
+)
+
+// Default returns the clock used by components that were not given one explicitly.
+func Default() Clock {
+	defaultClockMutex.RLock()
+	defer defaultClockMutex.RUnlock()
+	return defaultClock
+}
+
+// SetDefault replaces the clock used by components that were not given one explicitly and returns a
+// function that restores the previous clock. Passing nil restores the system clock. Components capture
+// the default when they are created, so this should be called before creating them.
+//
+// Example:
+//
+//	fake := clock.NewFake(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+//	restore := clock.SetDefault(fake)
+//	defer restore()
+//
+//	f := frame.Open("Build", frame.WithOutput(&buf))
+//	fake.Advance(1500 * time.Millisecond)
+//	f.Close() // Always reports (1.5s)
+func SetDefault(c Clock) (restore func()) {
+	if c == nil {
+		c = Real
+	}
+
+	defaultClockMutex.Lock()
+	previous := defaultClock
+	defaultClock = c
+	defaultClockMutex.Unlock()
+
+	return func() {
+		defaultClockMutex.Lock()
+		defaultClock = previous
+		defaultClockMutex.Unlock()
+	}
+}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) Since(t time.Time) time.Duration {
+	return time.Since(t)
+}
+
+func (realClock) NewTicker(d time.Duration) Ticker {
+	return realTicker{time.NewTicker(d)}
+}
+
+func (t realTicker) C() <-chan time.Time {
+	return t.Ticker.C
+}
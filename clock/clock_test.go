@@ -0,0 +1,102 @@
+package clock_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/pseudomuto/gooey/clock"
+	"github.com/stretchr/testify/require"
+)
+
+var epoch = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func TestRealClock(t *testing.T) {
+	before := time.Now()
+	now := Real.Now()
+	require.False(t, now.Before(before))
+	require.GreaterOrEqual(t, Real.Since(before), time.Duration(0))
+
+	ticker := Real.NewTicker(time.Millisecond)
+	defer ticker.Stop()
+
+	select {
+	case <-ticker.C():
+	case <-time.After(time.Second):
+		t.Fatal("expected a tick from the real ticker")
+	}
+}
+
+func TestSetDefault(t *testing.T) {
+	require.Equal(t, Real, Default())
+
+	fake := NewFake(epoch)
+	restore := SetDefault(fake)
+	require.Equal(t, fake, Default())
+
+	restore()
+	require.Equal(t, Real, Default())
+
+	restore = SetDefault(nil)
+	require.Equal(t, Real, Default())
+	restore()
+}
+
+func TestFakeNowAndSince(t *testing.T) {
+	fake := NewFake(epoch)
+	require.Equal(t, epoch, fake.Now())
+
+	fake.Advance(1500 * time.Millisecond)
+	require.Equal(t, epoch.Add(1500*time.Millisecond), fake.Now())
+	require.Equal(t, 1500*time.Millisecond, fake.Since(epoch))
+
+	fake.Set(epoch.Add(time.Hour))
+	require.Equal(t, time.Hour, fake.Since(epoch))
+}
+
+func TestFakeTickerDeliversDueTicks(t *testing.T) {
+	fake := NewFake(epoch)
+	ticker := fake.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+
+	var (
+		ticks []time.Time
+		wg    sync.WaitGroup
+	)
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for range 3 {
+			ticks = append(ticks, <-ticker.C())
+		}
+	}()
+
+	fake.Advance(50 * time.Millisecond)
+	fake.Advance(250 * time.Millisecond)
+	wg.Wait()
+
+	require.Equal(t, []time.Time{
+		epoch.Add(100 * time.Millisecond),
+		epoch.Add(200 * time.Millisecond),
+		epoch.Add(300 * time.Millisecond),
+	}, ticks)
+	require.Equal(t, epoch.Add(300*time.Millisecond), fake.Now())
+}
+
+func TestFakeTickerStop(t *testing.T) {
+	fake := NewFake(epoch)
+	ticker := fake.NewTicker(time.Millisecond)
+	ticker.Stop()
+	ticker.Stop() // safe to call twice
+
+	// Advancing must not block on a stopped ticker
+	fake.Advance(time.Second)
+	require.Equal(t, epoch.Add(time.Second), fake.Now())
+}
+
+func TestFakeTickerRequiresPositiveInterval(t *testing.T) {
+	require.Panics(t, func() {
+		NewFake(epoch).NewTicker(0)
+	})
+}
@@ -0,0 +1,153 @@
+package clock
+
+import (
+	"sync"
+	"time"
+)
+
+type (
+	// Fake is a manually controlled Clock for tests. Time only moves when Advance or Set is called.
+	//
+	// Tickers created from a Fake deliver their ticks synchronously from Advance: each tick blocks until
+	// it has been received (or the ticker is stopped). By the time Advance returns, every component
+	// driven by the clock has observed the ticks that were due.
+	Fake struct {
+		mutex   sync.Mutex
+		now     time.Time
+		tickers []*fakeTicker
+	}
+
+	fakeTicker struct {
+		clock  *Fake
+		period time.Duration
+		next   time.Time
+		c      chan time.Time
+		done   chan struct{}
+		once   sync.Once
+	}
+)
+
+// NewFake creates a fake clock set to the given time.
+//
+// Example:
+//
+//	fake := clock.NewFake(time.Unix(0, 0))
+//	s := spinner.New("Loading...", spinner.WithClock(fake), spinner.WithOutput(vt))
+//	s.Start()
+//	fake.Advance(100 * time.Millisecond) // renders exactly one more animation frame
+//	s.Stop()                             // prints "✓ Loading... (100ms)"
+func NewFake(now time.Time) *Fake {
+	return &Fake{now: now}
+}
+
+// Now returns the fake clock's current time.
+func (f *Fake) Now() time.Time {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return f.now
+}
+
+// Since returns the time elapsed between t and the fake clock's current time.
+func (f *Fake) Since(t time.Time) time.Duration {
+	return f.Now().Sub(t)
+}
+
+// NewTicker returns a Ticker whose ticks are delivered by Advance. Like time.NewTicker, it panics if d
+// is not positive.
+func (f *Fake) NewTicker(d time.Duration) Ticker {
+	if d <= 0 {
+		panic("clock: non-positive interval for NewTicker")
+	}
+
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	t := &fakeTicker{
+		clock:  f,
+		period: d,
+		next:   f.now.Add(d),
+		c:      make(chan time.Time),
+		done:   make(chan struct{}),
+	}
+	f.tickers = append(f.tickers, t)
+	return t
+}
+
+// Set moves the clock to the given time without firing any tickers.
+func (f *Fake) Set(now time.Time) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.now = now
+}
+
+// Advance moves the clock forward by d, delivering every tick that becomes due along the way in
+// chronological order.
+func (f *Fake) Advance(d time.Duration) {
+	f.mutex.Lock()
+	target := f.now.Add(d)
+	f.mutex.Unlock()
+
+	for {
+		t, at := f.nextDue(target)
+		if t == nil {
+			break
+		}
+
+		select {
+		case t.c <- at:
+		case <-t.done:
+		}
+	}
+
+	f.mutex.Lock()
+	if target.After(f.now) {
+		f.now = target
+	}
+	f.mutex.Unlock()
+}
+
+// nextDue returns the ticker with the earliest tick at or before target, moving the clock to that
+// tick's time. It returns nil when no ticks are due.
+func (f *Fake) nextDue(target time.Time) (*fakeTicker, time.Time) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	var due *fakeTicker
+	for _, t := range f.tickers {
+		if !t.next.After(target) && (due == nil || t.next.Before(due.next)) {
+			due = t
+		}
+	}
+
+	if due == nil {
+		return nil, time.Time{}
+	}
+
+	at := due.next
+	f.now = at
+	due.next = due.next.Add(due.period)
+	return due, at
+}
+
+func (f *Fake) removeTicker(t *fakeTicker) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	for i, ticker := range f.tickers {
+		if ticker == t {
+			f.tickers = append(f.tickers[:i], f.tickers[i+1:]...)
+			return
+		}
+	}
+}
+
+func (t *fakeTicker) C() <-chan time.Time {
+	return t.c
+}
+
+func (t *fakeTicker) Stop() {
+	t.once.Do(func() {
+		close(t.done)
+		t.clock.removeTicker(t)
+	})
+}
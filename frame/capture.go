@@ -0,0 +1,312 @@
+package frame
+
+import (
+	"bytes"
+	"io"
+	"log"
+	"os"
+	"sync"
+	"sync/atomic"
+	"syscall"
+
+	"github.com/pkg/errors"
+	"github.com/pseudomuto/gooey/terminal"
+)
+
+var (
+	// activeCapture is the capture session started by the outermost frame opened with WithCapture
+	activeCapture      atomic.Pointer[captureSession]
+	captureLifecycleMu sync.Mutex
+
+	// captureSentinel is written to a capture pipe to find out when everything written before it has
+	// been delivered
+	captureSentinel = []byte("\x00gooey:capture-sync\x00")
+)
+
+type (
+	captureSession struct {
+		owner      *Frame
+		streams    []*capturedStream
+		logOutput  io.Writer
+		restoreEnv func()
+		mutex      sync.Mutex // serialises frame writes that release the streams against stopping
+		stopped    bool
+	}
+
+	// capturedStream redirects one of the process's standard streams into a pipe and delivers what's
+	// written to it to the innermost open frame
+	capturedStream struct {
+		file     *os.File // the stream being captured, e.g. os.Stdout
+		original *os.File // a duplicate of the stream's original destination
+		reader   *os.File
+		writer   *os.File
+		restore  func() error
+		capture  func() error
+		stack    *Stack
+		synced   chan struct{}
+		done     chan struct{}
+	}
+)
+
+// WithCapture captures everything written to the process's standard output and error while the frame
+// is open, including output from the standard log package, and prints it inside the innermost open
+// frame instead. This keeps third-party libraries that write to os.Stdout or call log.Printf from
+// breaking the frame's borders.
+//
+// File descriptors 1 and 2 are redirected into pipes, so output from code that keeps its own reference
+// to os.Stdout or os.Stderr (and from subprocesses that inherit them) is captured too. Frames keep
+// writing to the terminal, including through writers that wrap os.Stdout or os.Stderr: the streams are
+// pointed back at the terminal while a frame draws, so output written by other goroutines in that
+// moment isn't captured. The original streams are restored when the
+// frame closes; frames opened with WithCapture while a capture is already running have no effect.
+//
+// Example:
+//
+//	f := frame.Open("Migrate", frame.WithCapture())
+//	defer f.Close()
+//
+//	log.Printf("connecting to %s", dsn) // Shows: │ 2024/01/01 12:00:00 connecting to ... │
+//	fmt.Println("applied 3 migrations") // Shows: │ applied 3 migrations                  │
+func WithCapture() FrameOption {
+	return func(f *Frame) {
+		f.capture = true
+	}
+}
+
+// startCapture starts capturing the standard streams into f's stack, unless a capture is running
+func (f *Frame) startCapture() {
+	captureLifecycleMu.Lock()
+	defer captureLifecycleMu.Unlock()
+
+	if activeCapture.Load() != nil {
+		return
+	}
+
+	// Output is about to become a pipe, so fix the detected terminal for components created meanwhile
+	session := &captureSession{owner: f, restoreEnv: terminal.SetDefault(terminal.Default().Detect())}
+	for _, file := range []*os.File{os.Stdout, os.Stderr} {
+		stream, err := captureStream(file, f.stack)
+		if err != nil {
+			// Leave the streams alone rather than capturing some of them
+			session.stop()
+			return
+		}
+
+		session.streams = append(session.streams, stream)
+	}
+
+	session.logOutput = log.Writer()
+	log.SetOutput(session.streams[1].writer)
+	activeCapture.Store(session)
+}
+
+// stopCapture restores the standard streams when f started the running capture
+func (f *Frame) stopCapture() {
+	captureLifecycleMu.Lock()
+	defer captureLifecycleMu.Unlock()
+
+	if session := activeCapture.Load(); session != nil && session.owner == f {
+		session.stop()
+	}
+}
+
+// syncCapture waits until everything written to the standard streams so far has been delivered to a
+// frame, so captured output lands in the frame that was innermost when it was written
+func syncCapture() {
+	if session := activeCapture.Load(); session != nil {
+		for _, stream := range session.streams {
+			stream.sync()
+		}
+	}
+}
+
+// writeOutput writes s to w, a frame's output. While a capture is running, a frame writing to a
+// captured stream writes to the stream's original destination, and any other writer is given the
+// original streams for the duration of the write, so a writer that wraps os.Stdout doesn't feed the
+// frame back into the capture.
+func writeOutput(w io.Writer, s string) error {
+	session := activeCapture.Load()
+	if session == nil {
+		_, err := io.WriteString(w, s)
+		return err
+	}
+
+	for _, stream := range session.streams {
+		if w == io.Writer(stream.file) {
+			_, err := io.WriteString(stream.original, s)
+			return err
+		}
+	}
+
+	return session.release(func() error {
+		_, err := io.WriteString(w, s)
+		return err
+	})
+}
+
+// release points the captured streams back at their original destinations while fn runs
+func (c *captureSession) release(fn func() error) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.stopped {
+		return fn()
+	}
+
+	for _, stream := range c.streams {
+		_ = stream.restore()
+	}
+
+	defer func() {
+		for _, stream := range c.streams {
+			_ = stream.capture()
+		}
+	}()
+
+	return fn()
+}
+
+func (c *captureSession) stop() {
+	c.mutex.Lock()
+	c.stopped = true
+	c.mutex.Unlock()
+
+	if c.logOutput != nil {
+		log.SetOutput(c.logOutput)
+	}
+
+	for _, stream := range c.streams {
+		_ = stream.restore()
+	}
+
+	activeCapture.CompareAndSwap(c, nil)
+	for _, stream := range c.streams {
+		stream.close()
+	}
+
+	c.restoreEnv()
+}
+
+func captureStream(file *os.File, stack *Stack) (*capturedStream, error) {
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		return nil, errors.Wrap(err, "creating capture pipe")
+	}
+
+	original, restore, capture, err := redirect(file, writer)
+	if err != nil {
+		_ = reader.Close()
+		_ = writer.Close()
+		return nil, err
+	}
+
+	s := &capturedStream{
+		file:     file,
+		original: original,
+		reader:   reader,
+		writer:   writer,
+		restore:  restore,
+		capture:  capture,
+		stack:    stack,
+		synced:   make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+
+	go s.read()
+	return s, nil
+}
+
+// redirect points file's descriptor at w. It returns a duplicate of the descriptor's original
+// destination, a function that points the descriptor back at it and one that points it at w again.
+func redirect(file, w *os.File) (*os.File, func() error, func() error, error) {
+	fd := int(file.Fd())
+	saved, err := syscall.Dup(fd)
+	if err != nil {
+		return nil, nil, nil, errors.Wrapf(err, "duplicating %s", file.Name())
+	}
+
+	capture := func() error {
+		return errors.Wrapf(dupTo(int(w.Fd()), fd), "redirecting %s", file.Name())
+	}
+
+	if err := capture(); err != nil {
+		_ = syscall.Close(saved)
+		return nil, nil, nil, err
+	}
+
+	restore := func() error {
+		return errors.Wrapf(dupTo(saved, fd), "restoring %s", file.Name())
+	}
+
+	return os.NewFile(uintptr(saved), file.Name()), restore, capture, nil
+}
+
+// read delivers the stream's output until its pipe is closed
+func (s *capturedStream) read() {
+	defer close(s.done)
+
+	buf := make([]byte, 32*1024)
+	var pending []byte
+	for {
+		n, err := s.reader.Read(buf)
+		pending = s.deliver(append(pending, buf[:n]...))
+		if err != nil {
+			s.write(pending)
+			return
+		}
+	}
+}
+
+// deliver writes p to the innermost frame, acknowledging any sync sentinels in it. It returns the end
+// of p when it could be the start of a sentinel split across reads.
+func (s *capturedStream) deliver(p []byte) []byte {
+	for {
+		i := bytes.Index(p, captureSentinel)
+		if i < 0 {
+			break
+		}
+
+		s.write(p[:i])
+		p = p[i+len(captureSentinel):]
+		s.synced <- struct{}{}
+	}
+
+	keep := 0
+	for n := min(len(p), len(captureSentinel)-1); n > 0; n-- {
+		if bytes.HasPrefix(captureSentinel, p[len(p)-n:]) {
+			keep = n
+			break
+		}
+	}
+
+	s.write(p[:len(p)-keep])
+	return append([]byte(nil), p[len(p)-keep:]...)
+}
+
+func (s *capturedStream) write(p []byte) {
+	if len(p) == 0 {
+		return
+	}
+
+	if f := s.stack.Current(); f != nil {
+		_, _ = f.Write(p)
+		return
+	}
+
+	_, _ = s.original.Write(p)
+}
+
+// sync waits until everything written to the stream before it has been delivered
+func (s *capturedStream) sync() {
+	if _, err := s.writer.Write(captureSentinel); err == nil {
+		<-s.synced
+	}
+}
+
+// close closes the pipe once the stream has been restored and waits for the rest of its output
+func (s *capturedStream) close() {
+	_ = s.writer.Close()
+	<-s.done
+	_ = s.reader.Close()
+	_ = s.original.Close()
+}
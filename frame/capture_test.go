@@ -0,0 +1,87 @@
+package frame_test
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/pseudomuto/gooey/ci"
+	. "github.com/pseudomuto/gooey/frame"
+	"github.com/pseudomuto/gooey/terminal"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFrameWithCapture(t *testing.T) {
+	flags, logOutput := log.Flags(), log.Writer()
+	log.SetFlags(0)
+	t.Cleanup(func() { log.SetFlags(flags) })
+
+	var buf bytes.Buffer
+	stack := NewStack()
+	options := []FrameOption{
+		WithOutput(&buf),
+		WithClock(newTestClock()),
+		WithTerminal(terminal.Environment{Width: 30, TTY: terminal.TTYOff, Color: terminal.ColorNone, CI: ci.None}),
+	}
+
+	outer := stack.Open("Migrate", append(options, WithCapture())...)
+	fmt.Println("connecting")
+
+	inner := stack.Open("Apply", append(options, WithCapture())...)
+	fmt.Fprintln(os.Stderr, "warning: slow query")
+	log.Print("applied 3 migrations")
+	inner.Close()
+
+	// Closing the inner frame leaves the outer frame's capture running
+	require.NotEqual(t, logOutput, log.Writer())
+	fmt.Println("done")
+	outer.Close()
+
+	require.Equal(t, logOutput, log.Writer())
+	require.Equal(t, strings.Join([]string{
+		"┌── Migrate ─────────────────┐",
+		"│ connecting                 │",
+		"│  ┌── Apply ──────────────┐ │",
+		"│  │ warning: slow query   │ │",
+		"│  │ applied 3 migrations  │ │",
+		"│  └───────────────────────┘ │",
+		"│ done                       │",
+		"└────────────────────────────┘",
+		"",
+	}, "\n"), buf.String())
+}
+
+// stdoutWrapper wraps a writer the way formatters and loggers wrap os.Stdout
+type stdoutWrapper struct {
+	io.Writer
+}
+
+func TestFrameWithCaptureWritingThroughAWrappedStdout(t *testing.T) {
+	reader, writer, err := os.Pipe()
+	require.NoError(t, err)
+
+	stdout := os.Stdout
+	os.Stdout = writer
+	t.Cleanup(func() { os.Stdout = stdout })
+
+	env := terminal.Environment{Width: 30, TTY: terminal.TTYOff, Color: terminal.ColorNone, CI: ci.None}
+	f := Open("Migrate", testOptions(stdoutWrapper{os.Stdout}, env, WithCapture())...)
+	fmt.Println("hello")
+	f.Close()
+
+	os.Stdout = stdout
+	require.NoError(t, writer.Close())
+
+	out, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	require.Equal(t, strings.Join([]string{
+		"┌── Migrate ─────────────────┐",
+		"│ hello                      │",
+		"└────────────────────────────┘",
+		"",
+	}, "\n"), string(out))
+}
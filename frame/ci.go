@@ -0,0 +1,63 @@
+package frame
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	"github.com/pseudomuto/gooey/ansi"
+	"github.com/pseudomuto/gooey/ci"
+)
+
+// groupCount numbers the CI log groups opened by frames, so their IDs are unique within the process
+var groupCount atomic.Int64
+
+// startGroup writes the CI provider's marker that starts folding the frame's content, when the frame is
+// rendered into a CI log. It's written as is, ahead of the opening border, since providers only
+// recognise markers at the start of a line.
+func (f *Frame) startGroup() {
+	provider := f.env.CIProvider()
+	if provider == nil {
+		return
+	}
+
+	f.group = &ci.Group{
+		ID:    fmt.Sprintf("gooey_%d", groupCount.Add(1)),
+		Title: ansi.StripStyles(formatTemplate(f.title)),
+		Depth: f.renderContext().Depth,
+		Time:  f.startTime,
+	}
+
+	f.routeMarker(provider.StartGroup(*f.group))
+}
+
+// endGroup writes the CI provider's marker that ends the group started by startGroup, after the closing
+// border
+func (f *Frame) endGroup(result Status) {
+	if f.group == nil {
+		return
+	}
+
+	group := *f.group
+	group.Time = f.clock.Now()
+	group.Failed = result != StatusSuccess
+	f.routeMarker(f.env.CIProvider().EndGroup(group))
+}
+
+// routeMarker writes a CI marker around the frame rather than inside it: into the enclosing frame, or
+// straight to the output for a top-level frame. This keeps the marker out of the rows the frame has
+// drawn and out of the content a collapsible frame holds back and discards when it folds.
+func (f *Frame) routeMarker(marker string) {
+	if marker == "" {
+		return
+	}
+
+	if f.parent != nil {
+		f.parent.settle()
+		_ = f.parent.route(marker)
+		return
+	}
+
+	f.stack.writeMutex.Lock()
+	defer f.stack.writeMutex.Unlock()
+	_ = writeOutput(f.output, marker)
+}
@@ -0,0 +1,64 @@
+package frame_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/pseudomuto/gooey/ci"
+	. "github.com/pseudomuto/gooey/frame"
+	"github.com/pseudomuto/gooey/terminal"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFramesFoldInCILogs(t *testing.T) {
+	var buf bytes.Buffer
+	env := terminal.Environment{Width: 30, TTY: terminal.TTYOff, Color: terminal.ColorNone, CI: ci.GitHubActions}
+	options := testOptions(&buf, env)
+
+	outer := Open("{{bold:Build}}", options...)
+	inner := Open("Lint", options...)
+	inner.Println("ok")
+	inner.Close()
+	outer.Close()
+
+	// Nested groups aren't supported by GitHub, so only the outer frame is folded
+	require.Equal(t, strings.Join([]string{
+		"::group::Build",
+		"┌── Build ───────────────────┐",
+		"│  ┌── Lint ───────────────┐ │",
+		"│  │ ok                    │ │",
+		"│  └───────────────────────┘ │",
+		"└────────────────────────────┘",
+		"::endgroup::",
+		"",
+	}, "\n"), buf.String())
+}
+
+func TestFramesFoldInCILogsWithFailures(t *testing.T) {
+	var buf bytes.Buffer
+	env := terminal.Environment{Width: 30, TTY: terminal.TTYOff, Color: terminal.ColorNone, CI: ci.Buildkite}
+
+	f := Open("Deploy", testOptions(&buf, env)...)
+	_ = f.CloseWithError(errors.New("boom"))
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	require.Equal(t, "--- Deploy", lines[0])
+	require.Equal(t, "^^^ +++", lines[len(lines)-1])
+}
+
+func TestCollapsedFramesFoldInCILogs(t *testing.T) {
+	for _, tty := range []terminal.TTYMode{terminal.TTYOff, terminal.TTYOn} {
+		var buf bytes.Buffer
+		env := terminal.Environment{Width: 30, TTY: tty, Color: terminal.ColorNone, CI: ci.GitHubActions}
+
+		f := Open("Build", testOptions(&buf, env, WithCollapse())...)
+		f.Println("compiling")
+		f.Close()
+
+		// The group surrounds the summary line the frame folds into
+		require.True(t, strings.HasPrefix(buf.String(), "::group::Build\n"), buf.String())
+		require.True(t, strings.HasSuffix(buf.String(), "✓ Build\n::endgroup::\n"), buf.String())
+	}
+}
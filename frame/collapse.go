@@ -0,0 +1,152 @@
+package frame
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/pseudomuto/gooey/ansi"
+)
+
+var (
+	// cursorRowRegex matches the cursor movements that change the cursor's row: up (A), down (B), next
+	// line (E) and previous line (F)
+	cursorRowRegex = regexp.MustCompile(`\x1b\[(\d*)([ABEF])`)
+
+	// savedCursorRegex matches output written between saving and restoring the cursor position, which
+	// leaves the cursor where it started
+	savedCursorRegex = regexp.MustCompile(`(?s)(\x1b\[s|\x1b7).*?(\x1b\[u|\x1b8)`)
+)
+
+// collapseState tracks the output of a collapsible frame (and the frames nested inside it) so it can be
+// folded into a summary line when the frame closes
+type collapseState struct {
+	mutex     sync.Mutex
+	buffering bool            // content is held back instead of written (non-TTY output)
+	buffer    strings.Builder // content held back while buffering
+	rows      int             // net rows the cursor has moved down since the frame opened
+	done      bool            // the frame has closed and no longer records output
+}
+
+// WithCollapse makes the frame fold into a single summary line when it closes successfully, so that long
+// output (e.g. build logs) doesn't bury what matters. If the frame closes with a failure or warning, its
+// full content is kept.
+//
+// On a TTY the content is shown while the frame is open, then erased with cursor movement when it
+// closes successfully. Content that has scrolled past the top of the terminal can't be erased and stays
+// in the scrollback. When the output isn't a TTY, the content is held back instead, and only written if
+// the frame doesn't close successfully.
+//
+// Example:
+//
+//	err := frame.Run("Build", func(f *frame.Frame) error {
+//		return runBuild(f) // streams compiler output into the frame
+//	}, frame.WithCollapse())
+//
+//	// On success the whole frame is replaced with: ✓ Build (12.3s)
+func WithCollapse() FrameOption {
+	return func(f *Frame) {
+		f.collapse = new(collapseState)
+	}
+}
+
+// route writes rendered output for f, after settling the in-place updates of the frames enclosing it.
+func (f *Frame) route(s string) error {
+	// Output from a nested frame ends the in-place updates of the frames around it
+	for fr := f.parent; fr != nil; fr = fr.parent {
+		fr.settle()
+	}
+
+	return f.deliver(func() string { return s })
+}
+
+// deliver writes the output returned by render while holding the stack's write lock, so render can rely
+// on the rows recorded so far. The rows the output moves the cursor by are recorded with f, the frames
+// enclosing it and the collapsible ones among them. If any collapsible frame is holding its content back,
+// the output goes into the outermost one's buffer rather than the underlying writer.
+func (f *Frame) deliver(render func() string) error {
+	f.stack.writeMutex.Lock()
+	defer f.stack.writeMutex.Unlock()
+
+	s := render()
+	if s == "" {
+		return nil
+	}
+
+	var target *collapseState
+	delta := rowDelta(s)
+	for fr := f; fr != nil; fr = fr.parent {
+		fr.rows += delta
+
+		c := fr.collapse
+		if c == nil {
+			continue
+		}
+
+		c.mutex.Lock()
+		if !c.done {
+			c.rows += delta
+			if c.buffering {
+				target = c
+			}
+		}
+		c.mutex.Unlock()
+	}
+
+	if target != nil {
+		target.mutex.Lock()
+		target.buffer.WriteString(s)
+		target.mutex.Unlock()
+		return nil
+	}
+
+	return writeOutput(f.output, s)
+}
+
+// finishCollapse stops recording output for a collapsible frame that has just been removed from its
+// stack. When fold is true, the frame's content is erased (or discarded when buffered) and summary is
+// written in its place; otherwise any held back content is written out so the frame can close normally.
+func (f *Frame) finishCollapse(fold bool, summary string) {
+	c := f.collapse
+	c.mutex.Lock()
+	c.done = true
+	rows, buffered := c.rows, c.buffer.String()
+	buffering := c.buffering
+	c.mutex.Unlock()
+
+	switch {
+	case !fold:
+		if buffering {
+			_ = f.route(buffered)
+		}
+	case buffering:
+		_ = f.route(summary)
+	case rows > 0:
+		_ = f.route(ansi.MoveCursorUp(rows) + "\r" + ansi.ClearToEndOfScreen + summary)
+	default:
+		_ = f.route(summary)
+	}
+}
+
+// rowDelta returns how many rows the cursor moves down (or up, when negative) when s is written, from
+// its newlines and cursor movement sequences
+func rowDelta(s string) int {
+	s = savedCursorRegex.ReplaceAllString(s, "")
+	delta := strings.Count(s, "\n")
+	for _, match := range cursorRowRegex.FindAllStringSubmatch(s, -1) {
+		n := 1
+		if match[1] != "" {
+			n, _ = strconv.Atoi(match[1])
+		}
+
+		switch match[2] {
+		case "A", "F":
+			delta -= n
+		default:
+			delta += n
+		}
+	}
+
+	return delta
+}
@@ -0,0 +1,100 @@
+package frame_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/pseudomuto/gooey/ci"
+	. "github.com/pseudomuto/gooey/frame"
+	"github.com/pseudomuto/gooey/terminal"
+	"github.com/pseudomuto/gooey/termtest"
+	"github.com/stretchr/testify/require"
+)
+
+// collapseTestEnv is the terminal the frames in these tests are opened in
+var collapseTestEnv = terminal.Environment{Width: 30, TTY: terminal.TTYOn, Color: terminal.ColorNone, CI: ci.None}
+
+func TestFrameCollapseOnSuccess(t *testing.T) {
+	fake := newTestClock()
+	vt := termtest.New(30)
+	options := testOptions(vt, collapseTestEnv, WithClock(fake))
+
+	outer := Open("Release", options...)
+	outer.Println("Preparing")
+
+	build := Open("Build", append(options, WithCollapse())...)
+	build.Println("compiling 1/2")
+	build.ReplaceLine("compiling 2/2")
+	step := Open("Link", options...)
+	step.Println("linking")
+	step.Close()
+	build.Println("done")
+	fake.Advance(12300 * time.Millisecond)
+
+	// While open, the full content is visible
+	require.Contains(t, vt.Screen().String(), "compiling 2/2")
+	build.Close()
+
+	outer.Println("Publishing")
+	outer.Close()
+
+	require.Equal(t, strings.Join([]string{
+		"┌── Release ─────────────────┐",
+		"│ Preparing                  │",
+		"│  ✓ Build (12.3s)           │",
+		"│ Publishing                 │",
+		"└─────────────────── (12.3s) ┘",
+		"",
+	}, "\n"), vt.Screen().String())
+}
+
+func TestFrameCollapseKeepsContentOnFailure(t *testing.T) {
+	vt := termtest.New(30)
+
+	err := Run("Build", func(f *Frame) error {
+		f.Println("compiling")
+		return errors.New("syntax error")
+	}, testOptions(vt, collapseTestEnv, WithCollapse())...)
+
+	require.EqualError(t, err, "syntax error")
+	require.Equal(t, strings.Join([]string{
+		"┌── Build ───────────────────┐",
+		"│ compiling                  │",
+		"│ ✗ syntax error             │",
+		"└───────────────────────── ✗ ┘",
+		"",
+	}, "\n"), vt.Screen().String())
+}
+
+func TestFrameCollapseWithoutTTY(t *testing.T) {
+	fake := newTestClock()
+	env := terminal.Environment{Width: 30, TTY: terminal.TTYOff, Color: terminal.ColorNone, CI: ci.None}
+
+	t.Run("success writes only the summary", func(t *testing.T) {
+		var buf bytes.Buffer
+		f := Open("Build", testOptions(&buf, env, WithClock(fake), WithCollapse())...)
+		f.Println("compiling")
+		require.Empty(t, buf.String())
+
+		fake.Advance(2 * time.Second)
+		f.Close()
+		require.Equal(t, "✓ Build (2s)\n", buf.String())
+	})
+
+	t.Run("failure writes the held back content", func(t *testing.T) {
+		var buf bytes.Buffer
+		f := Open("Build", testOptions(&buf, env, WithClock(fake), WithCollapse())...)
+		f.Println("compiling")
+		f.CloseWithStatus(StatusWarning)
+
+		require.Equal(t, strings.Join([]string{
+			"┌── Build ───────────────────┐",
+			"│ compiling                  │",
+			"└───────────────────────── ⚠ ┘",
+			"",
+		}, "\n"), buf.String())
+	})
+}
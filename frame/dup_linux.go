@@ -0,0 +1,8 @@
+package frame
+
+import "syscall"
+
+// dupTo makes newfd a copy of oldfd, closing newfd first if necessary
+func dupTo(oldfd, newfd int) error {
+	return syscall.Dup3(oldfd, newfd, 0)
+}
@@ -0,0 +1,10 @@
+//go:build unix && !linux
+
+package frame
+
+import "syscall"
+
+// dupTo makes newfd a copy of oldfd, closing newfd first if necessary
+func dupTo(oldfd, newfd int) error {
+	return syscall.Dup2(oldfd, newfd)
+}
@@ -0,0 +1,231 @@
+package frame
+
+import (
+	"bytes"
+	"os/exec"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/pseudomuto/gooey/ansi"
+)
+
+type (
+	// ExecOption configures how Exec and Command stream a command's output.
+	ExecOption func(*execConfig)
+
+	execConfig struct {
+		stderrColor  *ansi.Color
+		frameOptions []FrameOption
+	}
+
+	// execStreamer serialises output from a command's stdout and stderr into a frame
+	execStreamer struct {
+		frame  *Frame
+		mutex  sync.Mutex
+		active *execStream // the stream whose in-progress (\r-updated) line is the frame's last line
+	}
+
+	// execStream splits one of a command's output streams into lines. Complete lines are printed as they
+	// arrive; lines updated in place with \r (e.g. download progress) are updated in place on a TTY, and
+	// only their final state is printed otherwise.
+	execStream struct {
+		streamer *execStreamer
+		color    *ansi.Color
+		pending  []byte // output since the last \n or \r
+		latest   string // the latest in-place update that hasn't been printed (non-TTY only)
+	}
+)
+
+// Exec runs cmd, streaming its stdout and stderr into f line by line, and returns once it has exited.
+// Partial lines are held back until they're complete, and lines updated in place with carriage returns
+// (like the progress output of curl or npm) are updated in place on a TTY. When the output isn't a TTY,
+// only the final state of such lines is printed, keeping logs readable.
+//
+// The frame is left open; use Command to open and close a frame around the command. The command's
+// Stdout and Stderr must not be set. A non-zero exit status is returned as an error wrapping the
+// *exec.ExitError.
+//
+// Example:
+//
+//	f := frame.Open("Test")
+//	err := frame.Exec(f, exec.Command("go", "test", "./..."), frame.WithStderrColor(ansi.Red))
+//	f.CloseWithError(err)
+func Exec(f *Frame, cmd *exec.Cmd, options ...ExecOption) error {
+	config := newExecConfig(options)
+	if cmd.Stdout != nil || cmd.Stderr != nil {
+		return errors.New("frame: Stdout or Stderr already set")
+	}
+
+	streamer := &execStreamer{frame: f}
+	stdout := &execStream{streamer: streamer}
+	stderr := &execStream{streamer: streamer, color: config.stderrColor}
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+
+	err := cmd.Run()
+	stdout.flush()
+	stderr.flush()
+
+	if err != nil {
+		return errors.Wrapf(err, "%s failed", commandName(cmd))
+	}
+
+	return nil
+}
+
+// Command opens a frame with the given title, runs cmd in it with Exec and closes the frame with the
+// outcome: StatusSuccess when the command exits with status 0, and StatusFailure with the error printed
+// inside the frame otherwise. The error is returned.
+//
+// Example:
+//
+//	err := frame.Command("Install", exec.Command("npm", "install"),
+//		frame.WithStderrColor(ansi.Yellow),
+//		frame.WithFrameOptions(frame.WithColor(ansi.Blue)))
+//
+//	// On failure shows: │ ✗ npm failed                 │
+//	//                   │   caused by: exit status 1   │
+//	//                   └─────────────────── ✗ (4.2s) ┘
+func Command(title string, cmd *exec.Cmd, options ...ExecOption) error {
+	config := newExecConfig(options)
+	return Run(title, func(f *Frame) error {
+		return Exec(f, cmd, options...)
+	}, config.frameOptions...)
+}
+
+// WithStderrColor colours the lines a command writes to stderr, so they stand out from its stdout.
+// By default, stderr lines are printed like stdout lines.
+//
+// Example:
+//
+//	err := frame.Exec(f, cmd, frame.WithStderrColor(ansi.Red))
+func WithStderrColor(color ansi.Color) ExecOption {
+	return func(c *execConfig) {
+		c.stderrColor = &color
+	}
+}
+
+// WithFrameOptions sets the options used to open the frame created by Command.
+//
+// Example:
+//
+//	err := frame.Command("Build", cmd, frame.WithFrameOptions(frame.WithStyle(frame.Rounded)))
+func WithFrameOptions(options ...FrameOption) ExecOption {
+	return func(c *execConfig) {
+		c.frameOptions = append(c.frameOptions, options...)
+	}
+}
+
+func newExecConfig(options []ExecOption) *execConfig {
+	config := new(execConfig)
+	for _, option := range options {
+		option(config)
+	}
+
+	return config
+}
+
+// commandName returns the name cmd was created with, e.g. "go" for exec.Command("go", "test")
+func commandName(cmd *exec.Cmd) string {
+	if len(cmd.Args) > 0 {
+		return cmd.Args[0]
+	}
+
+	return cmd.Path
+}
+
+// Write implements io.Writer, printing each complete line of p to the frame.
+func (s *execStream) Write(p []byte) (int, error) {
+	s.streamer.mutex.Lock()
+	defer s.streamer.mutex.Unlock()
+
+	s.pending = append(s.pending, p...)
+	for {
+		i := bytes.IndexAny(s.pending, "\r\n")
+		if i < 0 {
+			break
+		}
+
+		line := string(s.pending[:i])
+		if s.pending[i] == '\n' {
+			s.pending = s.pending[i+1:]
+			s.commit(line)
+			continue
+		}
+
+		// Wait for the next write to tell whether this \r starts a \r\n line ending
+		if i == len(s.pending)-1 {
+			break
+		}
+
+		if s.pending[i+1] == '\n' {
+			s.pending = s.pending[i+2:]
+			s.commit(line)
+			continue
+		}
+
+		s.pending = s.pending[i+1:]
+		s.update(line)
+	}
+
+	return len(p), nil
+}
+
+// flush prints whatever is left once the command has exited
+func (s *execStream) flush() {
+	s.streamer.mutex.Lock()
+	defer s.streamer.mutex.Unlock()
+
+	line := string(bytes.TrimSuffix(s.pending, []byte("\r")))
+	s.pending = nil
+
+	switch {
+	case line != "":
+		s.commit(line)
+	case s.latest != "":
+		s.commit(s.latest)
+	case s.streamer.active == s:
+		s.streamer.active = nil
+	}
+}
+
+// commit prints a complete line, replacing this stream's in-progress line when it's still the last one
+func (s *execStream) commit(line string) {
+	if s.streamer.active == s && s.streamer.frame.env.IsTTY() {
+		s.streamer.frame.ReplaceLine("%s", s.colorize(line))
+	} else {
+		_, _ = s.streamer.frame.Write([]byte(s.colorize(line) + "\n"))
+	}
+
+	s.streamer.active = nil
+	s.latest = ""
+}
+
+// update shows an in-place update of the current line. On a TTY the line is replaced while it's still
+// the last line of the frame; otherwise only the latest update is kept until the line is complete.
+func (s *execStream) update(line string) {
+	if line == "" {
+		return
+	}
+
+	if !s.streamer.frame.env.IsTTY() {
+		s.latest = line
+		return
+	}
+
+	if s.streamer.active == s {
+		s.streamer.frame.ReplaceLine("%s", s.colorize(line))
+		return
+	}
+
+	_, _ = s.streamer.frame.Write([]byte(s.colorize(line) + "\n"))
+	s.streamer.active = s
+}
+
+func (s *execStream) colorize(line string) string {
+	if s.color == nil {
+		return line
+	}
+
+	return s.color.Colorize(line)
+}
@@ -0,0 +1,121 @@
+package frame_test
+
+import (
+	"bytes"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/pseudomuto/gooey/ansi"
+	"github.com/pseudomuto/gooey/ci"
+	. "github.com/pseudomuto/gooey/frame"
+	"github.com/pseudomuto/gooey/terminal"
+	"github.com/pseudomuto/gooey/termtest"
+	"github.com/stretchr/testify/require"
+)
+
+// execTestEnv is the terminal the frames in these tests are opened in
+var execTestEnv = terminal.Environment{Width: 30, TTY: terminal.TTYOn, Color: terminal.ColorANSI, CI: ci.None}
+
+func TestExec(t *testing.T) {
+	vt := termtest.New(30)
+	f := Open("Script", testOptions(vt, execTestEnv)...)
+
+	require.NoError(t, Exec(f, exec.Command("sh", "-c", `printf 'one\ntw'; printf 'o\r\n\nthree'`)))
+	require.NoError(t, Exec(f, exec.Command("sh", "-c", "echo oops >&2"), WithStderrColor(ansi.Red)))
+	f.Close()
+
+	require.Equal(t, strings.Join([]string{
+		"┌── Script ──────────────────┐",
+		"│ one                        │",
+		"│ two                        │",
+		"│                            │",
+		"│ three                      │",
+		"│ oops                       │",
+		"└────────────────────────────┘",
+		"",
+	}, "\n"), vt.Screen().String())
+	require.Equal(t, ansi.Reset, vt.Screen().Cell(4, 2).Style.Foreground)
+	require.Equal(t, ansi.Red, vt.Screen().Cell(5, 2).Style.Foreground)
+}
+
+func TestExecProgressUpdates(t *testing.T) {
+	script := `printf 'downloading\n'; printf '\r 10%%'; printf '\r 50%%'; printf '\r100%%\n'; printf 'done\n'`
+
+	t.Run("updates in place on a TTY", func(t *testing.T) {
+		vt := termtest.New(30)
+		f := Open("Fetch", testOptions(vt, execTestEnv)...)
+		require.NoError(t, Exec(f, exec.Command("sh", "-c", script)))
+		f.Close()
+
+		require.Equal(t, strings.Join([]string{
+			"┌── Fetch ───────────────────┐",
+			"│ downloading                │",
+			"│ 100%                       │",
+			"│ done                       │",
+			"└────────────────────────────┘",
+			"",
+		}, "\n"), vt.Screen().String())
+	})
+
+	t.Run("prints only the final state otherwise", func(t *testing.T) {
+		vt := termtest.New(30)
+		f := Open("Fetch", testOptions(vt, terminal.Environment{Width: 30, TTY: terminal.TTYOff, Color: terminal.ColorANSI, CI: ci.None})...)
+		require.NoError(t, Exec(f, exec.Command("sh", "-c", script+`; printf 'a\rb\r'`)))
+		f.Close()
+
+		require.Equal(t, strings.Join([]string{
+			"┌── Fetch ───────────────────┐",
+			"│ downloading                │",
+			"│ 100%                       │",
+			"│ done                       │",
+			"│ b                          │",
+			"└────────────────────────────┘",
+			"",
+		}, "\n"), vt.Screen().String())
+	})
+}
+
+func TestExecRejectsRedirectedOutput(t *testing.T) {
+	vt := termtest.New(30)
+	f := Open("Script", testOptions(vt, execTestEnv)...)
+	defer f.Close()
+
+	cmd := exec.Command("sh", "-c", "true")
+	cmd.Stdout = new(bytes.Buffer)
+	require.EqualError(t, Exec(f, cmd), "frame: Stdout or Stderr already set")
+}
+
+func TestCommand(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		vt := termtest.New(30)
+		err := Command("Build", exec.Command("sh", "-c", "echo built"),
+			WithFrameOptions(testOptions(vt, execTestEnv)...))
+
+		require.NoError(t, err)
+		require.Equal(t, strings.Join([]string{
+			"┌── Build ───────────────────┐",
+			"│ built                      │",
+			"└───────────────────────── ✓ ┘",
+			"",
+		}, "\n"), vt.Screen().String())
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		vt := termtest.New(30)
+		err := Command("Build", exec.Command("sh", "-c", "echo broken >&2; exit 3"),
+			WithFrameOptions(testOptions(vt, execTestEnv)...))
+
+		var exitErr *exec.ExitError
+		require.ErrorAs(t, err, &exitErr)
+		require.Equal(t, 3, exitErr.ExitCode())
+		require.Equal(t, strings.Join([]string{
+			"┌── Build ───────────────────┐",
+			"│ broken                     │",
+			"│ ✗ sh failed                │",
+			"│   caused by: exit status 3 │",
+			"└───────────────────────── ✗ ┘",
+			"",
+		}, "\n"), vt.Screen().String())
+	})
+}
@@ -12,43 +12,100 @@ import (
 	"time"
 
 	"github.com/pseudomuto/gooey/ansi"
+	"github.com/pseudomuto/gooey/ci"
+	"github.com/pseudomuto/gooey/clock"
 	"github.com/pseudomuto/gooey/internal/term"
+	"github.com/pseudomuto/gooey/terminal"
 )
 
 const (
 	Box     FrameStyle = iota
 	Bracket FrameStyle = iota
+	// Rounded draws full borders with rounded corners (╭╮╰╯)
+	Rounded
+	// Double draws full borders with double lines (╔═╗)
+	Double
+	// Heavy draws full borders with heavy lines (┏━┓)
+	Heavy
+	// ASCII draws full borders with plain ASCII characters (+-|) for terminals and log viewers that
+	// don't render box-drawing characters
+	ASCII
+	// Markdown renders frames as Markdown headings and <details> blocks, as does every frame nested inside
+	// them. See WithMarkdown.
+	Markdown
 )
 
 const (
-	// Frame prefix constants
-	frameBranch         = "├─ "
-	frameVerticalPrefix = "│  "
+	// StatusSuccess closes a frame in green with a check mark
+	StatusSuccess Status = iota
+	// StatusFailure closes a frame in red with a cross mark
+	StatusFailure
+	// StatusWarning closes a frame in yellow with a warning sign
+	StatusWarning
+)
+
+const (
+	// AlignLeft places content and headings at the start of the line
+	AlignLeft Alignment = iota
+	// AlignCenter centres content and headings within the frame
+	AlignCenter
+	// AlignRight places content and headings at the end of the line
+	AlignRight
 )
 
 var (
 	defaultFrameColor            = ansi.Cyan
 	defaultFrameStyle            = Box
 	defaultFrameOutput io.Writer = os.Stdout
-
-	stack              = new(frameStack)
-	frameColorOverride *ansi.Color
-	frameColorMutex    sync.RWMutex
 )
 
 type (
 	Frame struct {
 		title        string
+		badge        string
 		color        ansi.Color
 		startTime    time.Time
+		clock        clock.Clock
 		output       io.Writer
+		env          terminal.Environment
+		style        FrameStyle
+		align        Alignment
+		titleAlign   Alignment
+		padding      int  // blank columns either side of the content
+		paddingLines int  // blank lines above and below the content
 		needsNewline bool // tracks if the last write ended without a newline
-		renderer     frameRenderer
+		renderer     FrameRenderer
+		stack        *Stack
+		stackRef     bool   // the frame holds a reference to a stack resolved from its output
+		parent       *Frame // the enclosing frame on the stack when this frame was opened
+		collapse     *collapseState
+		tail         *tailState
+		capture      bool
+		rows         int // rows written since the start of the opening border, guarded by the stack
+		spinner      *titleSpinner
+		titleMutex   sync.RWMutex // guards the title, badge and spinner glyph, which may be redrawn concurrently
+		untrack      func()
+		mutex        sync.Mutex     // serialises writes, which may come from captured output, and guards tail
+		closeMutex   sync.Mutex     // serialises closing, since Interrupt runs on the signal handler's goroutine
+		openedAt     string         // where the frame was opened, recorded in debug mode
+		logLevel     *Level         // the lowest level of log line shown, inherited when nil
+		timestamps   *TimestampMode // the timestamps before log lines, inherited when nil
+		laps         *lapState
+		markdown     bool      // the frame renders as Markdown, see WithMarkdown
+		held         *heldLine // the last content line of a Markdown frame
+		contentWidth int       // see ContentWidth, which doesn't change once the frame is open
+		group        *ci.Group // the CI log group folding the frame's content, if any
 	}
 
 	FrameOption func(*Frame)
 
 	FrameStyle int
+
+	// Status is the outcome a frame is closed with, shown as a coloured glyph in its closing border.
+	Status int
+
+	// Alignment positions content lines, titles and divider headings within a frame.
+	Alignment int
 )
 
 // Open creates and renders a new frame with the given title.
@@ -85,31 +142,67 @@ type (
 // The returned frame implements io.Writer and provides Print/Println methods for content.
 func Open(title string, options ...FrameOption) *Frame {
 	frame := &Frame{
-		title:     title,
-		color:     defaultFrameColor,
-		startTime: time.Now(),
-		output:    defaultFrameOutput,
-		renderer:  defaultRenderer(),
+		title:  title,
+		color:  defaultFrameColor,
+		clock:  clock.Default(),
+		output: defaultFrameOutput,
+		env:    terminal.Default(),
+		style:  defaultFrameStyle,
 	}
 
 	for _, option := range options {
 		option(frame)
 	}
 
-	frameColorMutex.RLock()
-	if frameColorOverride != nil {
-		frame.color = *frameColorOverride
+	frame.startTime = frame.clock.Now()
+	if frame.laps != nil {
+		frame.laps.start = frame.startTime
+	}
+	frame.env = frame.env.Detect()
+	if debugFrames.Load() {
+		frame.openedAt = callerLocation()
+	}
+	if frame.renderer == nil {
+		frame.renderer = styleRenderer(frame.style)
+	}
+	if frame.stack == nil {
+		frame.stack = resolveStack(frame.output)
+		frame.stackRef = true
 	}
-	frameColorMutex.RUnlock()
 
-	stack.push(frame)
+	if frame.collapse != nil {
+		frame.collapse.buffering = !frame.env.IsTTY()
+	}
+	if !frame.env.IsTTY() && frame.spinner != nil {
+		// Spinners can't be animated without redrawing the border, so logs only get the final glyph
+		frame.spinner.static = true
+	}
+
+	syncCapture()
+	frame.parent = frame.stack.Current()
+	frame.setupMarkdown()
+	frame.stack.push(frame)
+	frame.untrack = terminal.Track(frame)
+	frame.startGroup()
+
+	ctx := frame.renderContext()
+	frame.contentWidth = max(ctx.Width-ctx.inset(frame.renderer), 1)
+	frame.emit(ctx.line(frame.renderer.Open(ctx)) + "\n")
+	frame.emitPaddingLines()
+	if frame.capture {
+		frame.startCapture()
+	}
+	if frame.spinner != nil && !frame.spinner.static {
+		frame.startSpinner()
+	}
 
-	fmt.Fprint(frame.output, frame.renderer.openFrame(frame.title, frame.color))
 	return frame
 }
 
 // Close closes the current frame and renders the closing border with elapsed time.
-// This method should always be called to properly close frames and maintain the frame stack.
+// This method should always be called to properly close frames and maintain the frame stack. Frames
+// opened inside this one that are still open (e.g. after an early return) are closed first and marked
+// as abandoned; see SetDebug to find where they were opened.
 //
 // Example:
 //
@@ -121,21 +214,127 @@ func Open(title string, options ...FrameOption) *Frame {
 //	frame.Println("Work completed")
 //	// When Close() is called, it will show: └─────────── (100ms) ┘
 func (f *Frame) Close() {
-	if stack.current() != f {
+	f.close("", nil, StatusSuccess)
+}
+
+// CloseWithStatus closes the frame like Close, recolouring the closing border and showing the status
+// glyph (✓, ✗ or ⚠) before the elapsed time.
+//
+// Example:
+//
+//	f := frame.Open("Lint")
+//	f.Println("2 deprecated calls found")
+//	f.CloseWithStatus(frame.StatusWarning) // Shows: └──── ⚠ (1.2s) ┘ in yellow
+func (f *Frame) CloseWithStatus(status Status) {
+	color := status.color()
+	f.close(status.icon().Colorize(color), &color, status)
+}
+
+// CloseWithError closes the frame and returns err. A nil error closes the frame with StatusSuccess.
+// Otherwise the error and each error it wraps are printed inside the frame, and the frame is closed with
+// StatusFailure.
+//
+// Example:
+//
+//	func deploy() (err error) {
+//		f := frame.Open("Deploy")
+//		defer func() { err = f.CloseWithError(err) }()
+//
+//		return errors.Wrap(connect(), "deploy failed")
+//	}
+//
+//	// Shows: │ ✗ deploy failed                 │
+//	//        │   caused by: connection refused │
+//	//        └──────────────────── ✗ (1.2s) ┘
+func (f *Frame) CloseWithError(err error) error {
+	if err == nil {
+		f.CloseWithStatus(StatusSuccess)
+		return nil
+	}
+
+	for i, message := range errorChain(err) {
+		if i == 0 {
+			f.Println("%s %s", ansi.CrossMark.Colorize(ansi.Red), message)
+		} else {
+			f.Println("  caused by: %s", message)
+		}
+	}
+
+	f.CloseWithStatus(StatusFailure)
+	return err
+}
+
+// Interrupt closes the frame with a failure marker. It is called by the terminal cleanup manager when
+// the process is interrupted or panics, after any components running inside the frame have finished.
+//
+// Example:
+//
+//	f := frame.Open("Deploy")
+//	f.Interrupt() // Shows: └── ✗ interrupted (1.2s) ──┘
+func (f *Frame) Interrupt() {
+	color := StatusFailure.color()
+	f.close(color.Colorize(StatusFailure.icon().String()+" interrupted"), &color, StatusFailure)
+}
+
+// close renders the closing border with an optional status shown before the elapsed time, and an
+// optional colour replacing the frame's own. The result replaces the title spinner, and collapsible
+// frames fold into a summary line when it is StatusSuccess.
+func (f *Frame) close(status string, color *ansi.Color, result Status) {
+	f.closeMutex.Lock()
+	defer f.closeMutex.Unlock()
+
+	if !f.closeInner() {
+		return
+	}
+
+	successful := result == StatusSuccess
+	syncCapture()
+	glyph := result.icon().Colorize(result.color())
+	f.stopSpinner(glyph)
+	f.settle()
+	f.emitLapSummary()
+	f.emitPaddingLines()
+	ctx := f.renderContext()
+	ctx.Status = status
+	if status == "" && f.spinner != nil && f.spinner.static {
+		// The opening border couldn't show the result, so the closing one does
+		ctx.Status = glyph
+	}
+	if color != nil {
+		ctx.Color = *color
+	}
+
+	closeOutput := ctx.line(f.renderer.Close(ctx)) + "\n"
+	if !f.stack.popIf(f) {
 		return
 	}
+	if f.stackRef {
+		f.stack.release()
+	}
 
-	elapsed := time.Since(f.startTime)
-	frameColorMutex.RLock()
-	color := f.color
-	if frameColorOverride != nil {
-		color = *frameColorOverride
+	// The group ends after whatever the frame closes with, which is just a summary line when collapsed
+	defer f.endGroup(result)
+
+	f.untrack()
+	f.stopCapture()
+	if f.collapse != nil {
+		f.finishCollapse(successful, f.env.ApplyProfile(f.summaryLine(ctx)))
+		if successful {
+			return
+		}
 	}
-	frameColorMutex.RUnlock()
 
-	closeOutput := f.renderer.closeFrame(elapsed, color)
-	stack.pop()
-	fmt.Fprint(f.output, closeOutput)
+	f.emit(closeOutput)
+}
+
+// summaryLine renders the single line a collapsed frame is folded into
+func (f *Frame) summaryLine(ctx RenderContext) string {
+	summary := ansi.CheckMark.Colorize(ansi.Green) + " " + formatTemplate(f.title)
+	if timing := closingTiming(ctx.Elapsed); timing != "" {
+		summary += " " + strings.TrimSpace(timing)
+	}
+
+	return ctx.line(summary) + "\n"
 }
 
 // Write implements io.Writer, automatically adding the colored content prefix to each line
@@ -144,8 +343,34 @@ func (f *Frame) Write(p []byte) (n int, err error) {
 		return 0, nil
 	}
 
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
 	content := string(p)
 
+	// Pass control sequences (e.g. hiding the cursor) straight through without a line prefix, unless
+	// they'd end up in a Markdown document
+	if term.IsControlSequence(content) {
+		if f.markdown {
+			return len(p), nil
+		}
+
+		if err := f.route(content); err != nil {
+			return 0, err
+		}
+		return len(p), nil
+	}
+
+	if f.held != nil {
+		f.holdLines(content)
+		return len(p), nil
+	}
+
+	if f.tail != nil {
+		f.writeTail(content)
+		return len(p), nil
+	}
+
 	// Split content into lines for processing
 	lines := strings.Split(content, "\n")
 	endsWithNewline := strings.HasSuffix(content, "\n")
@@ -177,28 +402,79 @@ func (f *Frame) Write(p []byte) (n int, err error) {
 		f.needsNewline = true
 	}
 
-	written, err := f.output.Write([]byte(output.String()))
-	if err != nil {
-		return written, err
+	if err := f.route(f.env.ApplyProfile(output.String())); err != nil {
+		return 0, err
 	}
 
 	return len(p), nil
 }
 
+// ContentWidth returns the number of columns available to a content line, inside the frame's borders,
+// its padding and the borders of the frames around it. Components such as spinners use it to fit their
+// lines to the frame.
+//
+// Example:
+//
+//	f := frame.Open("Build", frame.WithTerminal(terminal.Environment{Width: 40}))
+//	f.ContentWidth() // Returns: 37
+func (f *Frame) ContentWidth() int {
+	return f.contentWidth
+}
+
+// settle writes out the lines held back so they can be updated in place, by a tail viewport or in a
+// Markdown frame, before output that ends the updates, such as a divider or a nested frame
+func (f *Frame) settle() {
+	f.finishTail()
+	f.flushHeldLine()
+}
+
+// emit writes rendered borders and line updates to the underlying writer, applying the frame's colour
+// profile. These have no caller to report write errors to, so they're ignored like fmt.Fprint's.
+func (f *Frame) emit(s string) {
+	_ = f.route(f.env.ApplyProfile(s))
+}
+
+// emitPaddingLines writes the blank lines padding the top and bottom of the frame's content
+func (f *Frame) emitPaddingLines() {
+	if f.paddingLines > 0 {
+		f.emit(strings.Repeat(f.formatContentLine("")+"\n", f.paddingLines))
+	}
+}
+
 // formatContentLine formats a single line of content with proper prefix and suffix
 func (f *Frame) formatContentLine(content string) string {
-	// Get frame color
-	frameColorMutex.RLock()
-	color := f.color
-	if frameColorOverride != nil {
-		color = *frameColorOverride
-	}
-	frameColorMutex.RUnlock()
+	ctx := f.renderContext()
+	return ctx.line(f.renderer.Content(ctx, content))
+}
 
-	// Get this frame's depth in the stack
-	frameDepth := stack.frameDepth(f)
+// renderContext describes this frame's position in the stack for its renderer
+func (f *Frame) renderContext() RenderContext {
+	color := f.stack.frameColor(f)
 
-	return f.renderer.formatContentLineWithDepth(content, color, frameDepth)
+	f.titleMutex.RLock()
+	title, badge := f.title, f.badge
+	if f.spinner != nil && !f.spinner.static {
+		title = f.spinner.glyph() + " " + title
+	}
+	f.titleMutex.RUnlock()
+
+	parents := f.stack.parents(f)
+	ctx := RenderContext{
+		Title:      title,
+		Badge:      badge,
+		Color:      color,
+		Depth:      len(parents) + 1,
+		Parents:    parents,
+		Elapsed:    f.clock.Since(f.startTime),
+		Align:      f.align,
+		TitleAlign: f.titleAlign,
+		Padding:    f.padding,
+	}
+
+	// Each parent's edges take up room on every line of this frame
+	left, right := ctx.edges()
+	ctx.Width = max(f.env.Columns()-strlen(left)-strlen(right), minFrameWidth)
+	return ctx
 }
 
 // Print formats according to a format specifier and writes to the frame without adding a newline.
@@ -230,7 +506,8 @@ func (f *Frame) Println(format string, a ...any) {
 }
 
 // Divider renders a horizontal divider line within the current frame.
-// Dividers help organize content into logical sections. The heading parameter is optional.
+// Dividers help organize content into logical sections. The heading parameter is optional. In frames
+// opened with WithLaps, the divider also ends the current phase and shows how long it took.
 //
 // Examples:
 //
@@ -242,32 +519,37 @@ func (f *Frame) Println(format string, a ...any) {
 //	frame.Println("Footer content...")
 //	frame.Close()
 func (f *Frame) Divider(heading string) {
-	frameColorMutex.RLock()
-	color := f.color
-	if frameColorOverride != nil {
-		color = *frameColorOverride
-	}
-	frameColorMutex.RUnlock()
-
-	fmt.Fprint(f.output, f.renderer.createDivider(heading, color))
+	f.settle()
+	ctx := f.renderContext()
+	ctx.Lap = f.recordLap(heading)
+	f.emit(ctx.line(f.renderer.Divider(ctx, heading)) + "\n")
 }
 
 // ReplaceLine replaces the last line written to the frame with new content
 // This allows components like progress bars to update in place while maintaining frame formatting
 func (f *Frame) ReplaceLine(format string, a ...any) {
 	content := fmt.Sprintf(format, a...)
+	if f.held != nil {
+		f.replaceHeldLine(content)
+		return
+	}
+
+	if f.tail != nil {
+		f.replaceTailLine(content)
+		return
+	}
 
 	// Format the content with proper frame styling
 	formattedLine := f.formatContentLine(content)
 
 	// Check if we're in a TTY environment that supports ANSI escape sequences
-	if term.IsTTY() {
+	if f.env.IsTTY() {
 		// Write cursor control directly to the underlying output to bypass frame processing
 		// This ensures ANSI sequences are interpreted as control commands, not text
-		fmt.Fprint(f.output, ansi.MoveCursorUp(1)+ansi.ClearLine+formattedLine+"\n")
+		f.emit(ansi.MoveCursorUp(1) + ansi.ClearLine + formattedLine + "\n")
 	} else {
 		// Non-TTY environment: just append the update as a new line
-		fmt.Fprintf(f.output, "%s\n", formattedLine)
+		f.emit(formattedLine + "\n")
 	}
 }
 
@@ -281,78 +563,71 @@ func (f *Frame) ReplaceLineN(linePosition int, format string, a ...any) {
 		return
 	}
 
+	f.settle()
 	content := fmt.Sprintf(format, a...)
 	formattedLine := f.formatContentLine(content)
 
 	// Check if we're in a TTY environment that supports ANSI escape sequences
-	if term.IsTTY() {
+	if f.env.IsTTY() {
 		// Move up, clear line, write content, then move back down to original position
 		moveUp := ansi.MoveCursorUp(linePosition)
 		moveDown := ansi.MoveCursorDown(linePosition)
-		fmt.Fprint(f.output, moveUp+ansi.ClearLine+formattedLine+moveDown)
+		f.emit(moveUp + ansi.ClearLine + formattedLine + moveDown)
 	} else {
 		// Non-TTY environment: just append the update as a new line
-		fmt.Fprintf(f.output, "%s\n", formattedLine)
+		f.emit(formattedLine + "\n")
 	}
 }
 
 // ReplaceBlock replaces the last N lines with new content lines
 // This is more reliable than individual line replacements for multi-line content
 func (f *Frame) ReplaceBlock(lineCount int, lines []string) {
+	f.settle()
+	f.replaceBlock(lineCount, lines)
+}
+
+func (f *Frame) replaceBlock(lineCount int, lines []string) {
 	if lineCount < 1 {
 		return
 	}
 
-	// If no new lines, just clear the old content
-	if len(lines) == 0 {
-		if lineCount > 1 {
-			fmt.Fprint(f.output, ansi.MoveCursorUp(lineCount-1))
-		}
-		for i := 0; i < lineCount; i++ {
-			fmt.Fprint(f.output, ansi.ClearLine)
-			if i < lineCount-1 {
-				fmt.Fprint(f.output, "\n")
-			}
-		}
-		return
-	}
+	var out strings.Builder
 
 	// Move cursor to the beginning of the block we want to replace
 	if lineCount > 1 {
-		fmt.Fprint(f.output, ansi.MoveCursorUp(lineCount-1))
+		out.WriteString(ansi.MoveCursorUp(lineCount - 1))
 	}
 
 	// Clear all old lines first
 	for i := 0; i < lineCount; i++ {
-		fmt.Fprint(f.output, ansi.ClearLine)
+		out.WriteString(ansi.ClearLine)
 		if i < lineCount-1 {
-			fmt.Fprint(f.output, "\n")
+			out.WriteString("\n")
 		}
 	}
 
+	// If no new lines, just clear the old content
+	if len(lines) == 0 {
+		f.emit(out.String())
+		return
+	}
+
 	// Move cursor back to the beginning of the cleared area
 	if lineCount > 1 {
-		fmt.Fprint(f.output, ansi.MoveCursorUp(lineCount-1))
+		out.WriteString(ansi.MoveCursorUp(lineCount - 1))
 	}
 
 	// Write new content
 	for i, line := range lines {
-		formattedLine := f.formatContentLine(line)
-		fmt.Fprint(f.output, formattedLine)
+		out.WriteString(f.formatContentLine(line))
 
 		// Add newline except for the last line
 		if i < len(lines)-1 {
-			fmt.Fprint(f.output, "\n")
+			out.WriteString("\n")
 		}
 	}
 
-	// If new content has more lines than old content, add them
-	if len(lines) > lineCount {
-		for i := lineCount; i < len(lines); i++ {
-			formattedLine := f.formatContentLine(lines[i])
-			fmt.Fprint(f.output, "\n"+formattedLine)
-		}
-	}
+	f.emit(out.String())
 }
 
 // WithColor sets the color for the frame's border and content prefixes.
@@ -369,11 +644,13 @@ func WithColor(color ansi.Color) FrameOption {
 	}
 }
 
-// WithStyle sets the frame's rendering style.
+// WithStyle sets the frame's rendering style. Styles added with RegisterStyle can be used too.
 //
-// Two styles are available:
+// The following styles are built in:
 //   - frame.Box: Full box borders with complete enclosure (default)
 //   - frame.Bracket: Simple bracket-style markers without full borders
+//   - frame.Rounded, frame.Double, frame.Heavy: Full borders with rounded, double or heavy lines
+//   - frame.ASCII: Full borders drawn with +, - and | only
 //
 // Examples:
 //
@@ -390,11 +667,77 @@ func WithColor(color ansi.Color) FrameOption {
 //	//          └──
 func WithStyle(style FrameStyle) FrameOption {
 	return func(f *Frame) {
-		width := term.Width()
-		f.renderer = &boxRenderer{termWidth: width}
-		if style == Bracket {
-			f.renderer = &bracketRenderer{termWidth: width}
-		}
+		f.style = style
+		f.renderer = nil
+	}
+}
+
+// WithPadding adds blank space around the frame's content: horizontal columns between each border and
+// the content, and vertical blank lines after the opening border and before the closing one. Frames
+// nested inside are inset by the horizontal padding too, so their borders line up with the content.
+//
+// Example:
+//
+//	f := frame.Open("Summary", frame.WithPadding(2, 1))
+//	f.Println("All checks passed")
+//	f.Close()
+//
+//	// Shows: ┌── Summary ──────────────┐
+//	//        │                         │
+//	//        │   All checks passed     │
+//	//        │                         │
+//	//        └─────────────────────────┘
+func WithPadding(horizontal, vertical int) FrameOption {
+	return func(f *Frame) {
+		f.padding = max(horizontal, 0)
+		f.paddingLines = max(vertical, 0)
+	}
+}
+
+// WithAlign sets the alignment of the frame's content lines. Content is left-aligned by default.
+//
+// Example:
+//
+//	f := frame.Open("Release", frame.WithAlign(frame.AlignCenter))
+//	f.Println("v1.2.0")
+//	f.Close()
+//
+//	// Shows: ┌── Release ──────────────┐
+//	//        │         v1.2.0          │
+//	//        └─────────────────────────┘
+func WithAlign(align Alignment) FrameOption {
+	return func(f *Frame) {
+		f.align = align
+	}
+}
+
+// WithTitleAlign sets the alignment of the frame's title and divider headings within their borders.
+// Titles are left-aligned by default.
+//
+// Example:
+//
+//	f := frame.Open("Release", frame.WithTitleAlign(frame.AlignCenter))
+//	f.Divider("Notes")
+//	f.Close()
+//
+//	// Shows: ┌─────── Release ────────┐
+//	//        ├──────── Notes ─────────┤
+//	//        └────────────────────────┘
+func WithTitleAlign(align Alignment) FrameOption {
+	return func(f *Frame) {
+		f.titleAlign = align
+	}
+}
+
+// WithRenderer sets a custom renderer for the frame, overriding its style.
+// See FrameRenderer for an example renderer.
+//
+// Example:
+//
+//	f := frame.Open("Deploy", frame.WithRenderer(arrowRenderer{}))
+func WithRenderer(renderer FrameRenderer) FrameOption {
+	return func(f *Frame) {
+		f.renderer = renderer
 	}
 }
 
@@ -421,10 +764,48 @@ func WithOutput(output io.Writer) FrameOption {
 	}
 }
 
-func defaultRenderer() frameRenderer {
-	if defaultFrameStyle == Box {
-		return &boxRenderer{termWidth: term.Width()}
+// WithStack opens the frame on the given stack instead of the one resolved from its output, so that it
+// only nests inside frames opened on the same stack. See Stack for details.
+//
+// Example:
+//
+//	stack := frame.NewStack()
+//	outer := frame.Open("Worker", frame.WithStack(stack), frame.WithOutput(&buf))
+//	inner := frame.Open("Step", frame.WithStack(stack), frame.WithOutput(&buf)) // nested inside outer
+func WithStack(stack *Stack) FrameOption {
+	return func(f *Frame) {
+		f.stack = stack
 	}
+}
 
-	return &bracketRenderer{termWidth: term.Width()}
+// WithClock sets the clock used to measure the frame's elapsed time.
+// By default, frames use clock.Default(), which is the system clock unless overridden.
+//
+// Example:
+//
+//	fake := clock.NewFake(time.Now())
+//	f := frame.Open("Build", frame.WithClock(fake))
+//	fake.Advance(2 * time.Second)
+//	f.Close() // Shows: └──── (2s) ┘
+func WithClock(c clock.Clock) FrameOption {
+	return func(f *Frame) {
+		if c != nil {
+			f.clock = c
+		}
+	}
+}
+
+// WithTerminal overrides the terminal environment the frame renders into.
+// By default, frames use terminal.Default(), which detects the width, TTY status and colour support
+// from the running process unless overridden.
+//
+// Example:
+//
+//	// Fixed width and forced TTY so ReplaceLine updates in place, regardless of where tests run
+//	env := terminal.Environment{Width: 60, TTY: terminal.TTYOn}
+//	f := frame.Open("Build", frame.WithTerminal(env), frame.WithOutput(&buf))
+func WithTerminal(env terminal.Environment) FrameOption {
+	return func(f *Frame) {
+		f.env = env
+	}
 }
@@ -2,12 +2,18 @@ package frame_test
 
 import (
 	"bytes"
+	"io"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/pseudomuto/gooey/ansi"
+	"github.com/pseudomuto/gooey/ci"
+	"github.com/pseudomuto/gooey/clock"
 	. "github.com/pseudomuto/gooey/frame"
+	"github.com/pseudomuto/gooey/terminal"
+	"github.com/pseudomuto/gooey/termtest"
 	"github.com/stretchr/testify/require"
 )
 
@@ -127,6 +133,26 @@ func TestFrameTiming(t *testing.T) {
 	require.Contains(t, output, ")")
 }
 
+func TestFrameTimingWithFakeClock(t *testing.T) {
+	fake := newTestClock()
+	restore := clock.SetDefault(fake)
+	defer restore()
+
+	var buf bytes.Buffer
+	frame := Open("Timed Frame", WithOutput(&buf))
+	fake.Advance(1500 * time.Millisecond)
+	frame.Close()
+
+	require.Contains(t, buf.String(), "(1.5s)")
+
+	buf.Reset()
+	frame = Open("Explicit Clock", WithOutput(&buf), WithClock(clock.NewFake(time.Now())))
+	fake.Advance(time.Second)
+	frame.Close()
+
+	require.NotContains(t, buf.String(), "(", "per-frame clock should take precedence over the default")
+}
+
 func TestFramePrint(t *testing.T) {
 	var buf bytes.Buffer
 	frame := Open("Test Frame", WithOutput(&buf))
@@ -222,3 +248,452 @@ func TestFrameTitleColoring(t *testing.T) {
 	// appears outside of color escape sequences
 	require.Contains(t, titleLine, "Test Frame", "Title should be present")
 }
+
+func TestFrameReplaceLine(t *testing.T) {
+	tests := []struct {
+		name     string
+		tty      terminal.TTYMode
+		expected string
+	}{
+		{
+			name: "TTY updates in place",
+			tty:  terminal.TTYOn,
+			expected: "┌── Status ────────────────┐\n" +
+				"│ done                     │\n",
+		},
+		{
+			name: "non-TTY appends updates",
+			tty:  terminal.TTYOff,
+			expected: "┌── Status ────────────────┐\n" +
+				"│ working                  │\n" +
+				"│ done                     │\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vt := termtest.New(28)
+			env := terminal.Environment{Width: 28, TTY: tt.tty, CI: ci.None}
+
+			f := Open("Status", WithOutput(vt), WithTerminal(env))
+			defer f.Close()
+
+			f.Println("working")
+			f.ReplaceLine("done")
+
+			require.Equal(t, tt.expected, vt.Screen().String())
+		})
+	}
+}
+
+func TestFrameColorProfile(t *testing.T) {
+	var buf bytes.Buffer
+	env := terminal.Environment{Width: 40, Color: terminal.ColorNone, CI: ci.None}
+
+	f := Open("Plain", WithColor(ansi.Red), WithOutput(&buf), WithTerminal(env))
+	f.Println("{{bold:content}}")
+	f.Close()
+
+	require.NotContains(t, buf.String(), "\033[")
+	require.Contains(t, buf.String(), "│ content")
+}
+
+func TestFrameSnapshot(t *testing.T) {
+	fake := newTestClock()
+	restoreClock := clock.SetDefault(fake)
+	defer restoreClock()
+
+	restoreEnv := terminal.SetDefault(terminal.Environment{Width: 40, TTY: terminal.TTYOn, CI: ci.None})
+	defer restoreEnv()
+
+	vt := termtest.New(40)
+	outer := Open("Deploy", WithOutput(vt))
+	outer.Println("Starting deployment...")
+
+	inner := Open("Database", WithOutput(vt), WithColor(ansi.Green))
+	inner.Println("Migrating...")
+	inner.ReplaceLine("{{check:}} Migrated")
+	fake.Advance(250 * time.Millisecond)
+	inner.Close()
+
+	outer.Divider("Services")
+	outer.Println("All services healthy")
+	fake.Advance(time.Second)
+	outer.Close()
+
+	termtest.Golden(t, "nested_frames", vt.Screen().String())
+}
+
+func TestFrameInterrupt(t *testing.T) {
+	fake := newTestClock()
+	env := terminal.Environment{Width: 40, Color: terminal.ColorNone, CI: ci.None}
+
+	vt := termtest.New(40)
+	outer := Open("Deploy", WithOutput(vt), WithClock(fake), WithTerminal(env))
+	inner := Open("Migrate", WithOutput(vt), WithClock(fake), WithTerminal(env))
+	inner.Println("Running migrations...")
+	fake.Advance(2 * time.Second)
+
+	terminal.Interrupt()
+
+	require.Equal(t, strings.Join([]string{
+		"┌── Deploy ────────────────────────────┐",
+		"│  ┌── Migrate ──────────────────────┐ │",
+		"│  │ Running migrations...           │ │",
+		"│  └───────────── ✗ interrupted (2s) ┘ │",
+		"└────────────────── ✗ interrupted (2s) ┘",
+		"",
+	}, "\n"), vt.Screen().String())
+
+	// Closing after an interrupt does nothing
+	raw := vt.Raw()
+	inner.Close()
+	outer.Close()
+	require.Equal(t, raw, vt.Raw())
+}
+
+func TestFrameInterruptWhileWriting(t *testing.T) {
+	var buf bytes.Buffer
+	env := terminal.Environment{Width: 40, TTY: terminal.TTYOff, Color: terminal.ColorNone, CI: ci.None}
+	f := Open("Deploy", WithOutput(&buf), WithStack(NewStack()), WithTerminal(env), WithLaps(true))
+
+	// Interrupts arrive on the signal handler's goroutine while the caller keeps writing and closing
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := range 100 {
+			f.Divider("step")
+			f.Println("line %d", i)
+		}
+		f.Close()
+	}()
+
+	f.Interrupt()
+	<-done
+
+	require.Contains(t, buf.String(), "✗ interrupted")
+	require.Equal(t, 1, strings.Count(buf.String(), "└"))
+}
+
+type arrowRenderer struct {
+	contexts []RenderContext
+}
+
+func (r *arrowRenderer) Open(ctx RenderContext) string {
+	r.contexts = append(r.contexts, ctx)
+	return ctx.Color.Sprint(">> ") + ctx.Title
+}
+
+func (r *arrowRenderer) Close(ctx RenderContext) string {
+	return ctx.Color.Sprint("<< ") + ctx.Elapsed.String()
+}
+
+func (r *arrowRenderer) Divider(ctx RenderContext, heading string) string {
+	return ctx.Color.Sprint("-- ") + heading
+}
+
+func (r *arrowRenderer) Content(ctx RenderContext, line string) string {
+	return ctx.Color.Sprint("   ") + line
+}
+
+func (r *arrowRenderer) Edges(color ansi.Color) (string, string) {
+	return color.Sprint(" : "), ""
+}
+
+func TestFrameWithRenderer(t *testing.T) {
+	fake := newTestClock()
+	env := terminal.Environment{Width: 30, CI: ci.None}
+	renderer := new(arrowRenderer)
+
+	vt := termtest.New(30)
+	outer := Open("Release", WithOutput(vt), WithClock(fake), WithTerminal(env), WithRenderer(renderer))
+	outer.Println("Tagging v1.2.0")
+	outer.Divider("Publish")
+
+	inner := Open("Upload", WithOutput(vt), WithClock(fake), WithTerminal(env), WithColor(ansi.Green))
+	inner.Println("Uploaded")
+	fake.Advance(time.Second)
+	inner.Close()
+	outer.Close()
+
+	require.Equal(t, strings.Join([]string{
+		">> Release",
+		"   Tagging v1.2.0",
+		"-- Publish",
+		" : ┌── Upload ───────────────┐",
+		" : │ Uploaded                │",
+		" : └─────────────────── (1s) ┘",
+		"<< 1s",
+		"",
+	}, "\n"), vt.Screen().String())
+
+	require.Len(t, renderer.contexts, 1)
+	ctx := renderer.contexts[0]
+	require.Equal(t, "Release", ctx.Title)
+	require.Equal(t, 1, ctx.Depth)
+	require.Empty(t, ctx.Parents)
+	require.Equal(t, 30, ctx.Width)
+}
+
+func TestRegisterStyle(t *testing.T) {
+	renderer := new(arrowRenderer)
+	style := RegisterStyle(renderer)
+	require.NotEqual(t, Box, style)
+	require.NotEqual(t, Bracket, style)
+	require.NotEqual(t, style, RegisterStyle(new(arrowRenderer)))
+
+	var buf bytes.Buffer
+	f := Open("Custom", WithOutput(&buf), WithStyle(style), WithTerminal(terminal.Environment{Color: terminal.ColorNone, CI: ci.None}))
+	f.Close()
+
+	require.Len(t, renderer.contexts, 1)
+	require.Contains(t, buf.String(), ">> Custom")
+}
+
+func TestFrameMixedStyleNesting(t *testing.T) {
+	fake := newTestClock()
+	env := terminal.Environment{Width: 30, Color: terminal.ColorNone, CI: ci.None}
+
+	vt := termtest.New(30)
+	outer := Open("Box", WithOutput(vt), WithTerminal(env), WithClock(fake))
+	middle := Open("Bracket", WithOutput(vt), WithTerminal(env), WithClock(fake), WithStyle(Bracket))
+	inner := Open("Inner Box", WithOutput(vt), WithTerminal(env), WithClock(fake))
+	inner.Println("deeply nested")
+	inner.Close()
+	middle.Println("bracket content")
+	middle.Close()
+	outer.Close()
+
+	require.Equal(t, strings.Join([]string{
+		"┌── Box ─────────────────────┐",
+		"│  ┌── Bracket               │",
+		"│  │  ┌── Inner Box ───────┐ │",
+		"│  │  │ deeply nested      │ │",
+		"│  │  └────────────────────┘ │",
+		"│  │ bracket content         │",
+		"│  └──                       │",
+		"└────────────────────────────┘",
+		"",
+	}, "\n"), vt.Screen().String())
+}
+
+func TestFrameBuiltInStyles(t *testing.T) {
+	fake := newTestClock()
+	env := terminal.Environment{Width: 40, Color: terminal.ColorNone, CI: ci.None}
+
+	vt := termtest.New(40)
+	open := func(title string, style FrameStyle) *Frame {
+		return Open(title, WithOutput(vt), WithClock(fake), WithTerminal(env), WithStyle(style))
+	}
+
+	for _, style := range []FrameStyle{Box, Bracket, Rounded, Double, Heavy, ASCII} {
+		f := open("Standalone", style)
+		f.Println("content")
+		f.Divider("section")
+		f.Close()
+	}
+
+	// Mixed styles draw each parent's own edges
+	ascii := open("ASCII", ASCII)
+	heavy := open("Heavy", Heavy)
+	rounded := open("Rounded", Rounded)
+	double := open("Double", Double)
+	double.Println("nested four deep")
+	fake.Advance(time.Second)
+	double.Close()
+	rounded.Close()
+	heavy.Close()
+	ascii.Close()
+
+	termtest.Golden(t, "frame_styles", vt.Screen().String())
+}
+
+func TestStackIsolatesFrameTrees(t *testing.T) {
+	fake := newTestClock()
+	env := terminal.Environment{Width: 30, Color: terminal.ColorNone, CI: ci.None}
+	render := func(stack *Stack, name string) string {
+		vt := termtest.New(30)
+		outer := Open(name, WithStack(stack), WithOutput(vt), WithTerminal(env), WithClock(fake))
+		for i := range 3 {
+			inner := stack.Open("Step", WithOutput(vt), WithTerminal(env), WithClock(fake))
+			inner.Println("step %d", i+1)
+			inner.Close()
+		}
+		outer.Close()
+		return vt.Screen().String()
+	}
+
+	expected := render(NewStack(), "Worker")
+
+	var wg sync.WaitGroup
+	results := make([]string, 8)
+	for i := range results {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = render(NewStack(), "Worker")
+		}()
+	}
+	wg.Wait()
+
+	for _, result := range results {
+		require.Equal(t, expected, result)
+	}
+}
+
+func TestStackFor(t *testing.T) {
+	fake := newTestClock()
+	restore := clock.SetDefault(fake)
+	defer restore()
+
+	env := terminal.Environment{Width: 30, Color: terminal.ColorNone, CI: ci.None}
+	console := termtest.New(30)
+	log := termtest.New(30)
+
+	logStack := StackFor(log)
+	require.Same(t, logStack, StackFor(log))
+	require.NotSame(t, DefaultStack(), logStack)
+
+	outer := Open("Console", WithOutput(console), WithTerminal(env))
+	logFrame := Open("Log", WithOutput(log), WithTerminal(env))
+	require.Equal(t, 1, logStack.Depth())
+	require.Same(t, logFrame, logStack.Current())
+	require.Same(t, outer, StackFor(console).Current())
+
+	logFrame.Println("not nested in console")
+	logFrame.Close()
+	outer.Close()
+
+	require.Zero(t, logStack.Depth())
+	require.Nil(t, logStack.Current())
+	require.Equal(t, strings.Join([]string{
+		"┌── Log ─────────────────────┐",
+		"│ not nested in console      │",
+		"└────────────────────────────┘",
+		"",
+	}, "\n"), log.Screen().String())
+}
+
+func TestFramesNestOnTheirOutputsStack(t *testing.T) {
+	env := terminal.Environment{Width: 30, Color: terminal.ColorNone, CI: ci.None}
+	options := func(w io.Writer) []FrameOption {
+		return []FrameOption{WithOutput(w), WithTerminal(env), WithClock(newTestClock())}
+	}
+
+	var build, deploy bytes.Buffer
+	outer := Open("Build", options(&build)...)
+	other := Open("Deploy", options(&deploy)...)
+	inner := Open("Test", options(&build)...)
+	inner.Close()
+	other.Close()
+	outer.Close()
+
+	require.Equal(t, strings.Join([]string{
+		"┌── Build ───────────────────┐",
+		"│  ┌── Test ───────────────┐ │",
+		"│  └───────────────────────┘ │",
+		"└────────────────────────────┘",
+		"",
+	}, "\n"), build.String())
+	require.Equal(t, strings.Join([]string{
+		"┌── Deploy ──────────────────┐",
+		"└────────────────────────────┘",
+		"",
+	}, "\n"), deploy.String())
+
+	// Neither tree touched the default stack
+	require.Nil(t, DefaultStack().Current())
+}
+
+func TestFrameAlignmentAndPadding(t *testing.T) {
+	tests := []struct {
+		name     string
+		style    FrameStyle
+		expected []string
+	}{
+		{
+			name:  "box",
+			style: Box,
+			expected: []string{
+				"┌─────── Report ───────┐",
+				"│                      │",
+				"│          ok          │",
+				"├────── Details ───────┤",
+				"│    ┌─── Nested ──┐   │",
+				"│    │ inner       │   │",
+				"│    └─────────────┘   │",
+				"│                      │",
+				"└──────────────────────┘",
+				"",
+			},
+		},
+		{
+			name:  "bracket",
+			style: Bracket,
+			expected: []string{
+				"┌─────── Report",
+				"│",
+				"│           ok",
+				"├─────── Details",
+				"│    ┌─────── Nested ──┐",
+				"│    │ inner           │",
+				"│    └─────────────────┘",
+				"│",
+				"└──",
+				"",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vt := termtest.New(24)
+			stack := NewStack()
+			env := terminal.Environment{Width: 24, Color: terminal.ColorNone, CI: ci.None}
+			fake := newTestClock()
+
+			f := Open("Report",
+				WithOutput(vt), WithStack(stack), WithClock(fake), WithTerminal(env), WithStyle(tt.style),
+				WithPadding(2, 1), WithAlign(AlignCenter), WithTitleAlign(AlignCenter))
+			f.Println("ok")
+			f.Divider("Details")
+
+			inner := Open("Nested",
+				WithOutput(vt), WithStack(stack), WithClock(fake), WithTerminal(env), WithTitleAlign(AlignRight))
+			inner.Println("inner")
+			inner.Close()
+			f.Close()
+
+			require.Equal(t, strings.Join(tt.expected, "\n"), vt.Screen().String())
+		})
+	}
+}
+
+func TestFrameContentWidth(t *testing.T) {
+	env := terminal.Environment{Width: 40, TTY: terminal.TTYOff, Color: terminal.ColorNone, CI: ci.None}
+
+	box := Open("Box", testOptions(io.Discard, env)...)
+	require.Equal(t, 37, box.ContentWidth())
+	box.Close()
+
+	padded := Open("Padded", testOptions(io.Discard, env, WithPadding(2, 0))...)
+	require.Equal(t, 33, padded.ContentWidth())
+	padded.Close()
+
+	bracket := Open("Bracket", testOptions(io.Discard, env, WithStyle(Bracket))...)
+	require.Equal(t, 38, bracket.ContentWidth())
+	bracket.Close()
+
+	// Custom renderers report how much of the width they take up
+	arrow := Open("Arrow", testOptions(io.Discard, env, WithRenderer(new(arrowRenderer)))...)
+	require.Equal(t, 37, arrow.ContentWidth())
+	arrow.Close()
+
+	// Nested frames lose the width of the borders around them
+	options := testOptions(io.Discard, env)
+	outer := Open("Outer", options...)
+	inner := Open("Inner", options...)
+	require.Equal(t, 32, inner.ContentWidth())
+	inner.Close()
+	outer.Close()
+}
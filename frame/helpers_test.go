@@ -0,0 +1,46 @@
+package frame_test
+
+import (
+	"io"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/pseudomuto/gooey/ci"
+	"github.com/pseudomuto/gooey/clock"
+	. "github.com/pseudomuto/gooey/frame"
+	"github.com/pseudomuto/gooey/terminal"
+)
+
+// TestMain renders as on a local terminal, even when the tests run in CI
+func TestMain(m *testing.M) {
+	terminal.SetDefault(terminal.Environment{CI: ci.None})
+	os.Exit(m.Run())
+}
+
+// newTestClock returns a fake clock set to midnight on 2024-01-01 UTC, so elapsed times are deterministic
+func newTestClock() *clock.Fake {
+	return clock.NewFake(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+}
+
+// testOptions returns the options for a frame that writes to w on its own stack, with a fake clock and
+// env as its terminal. Any options given are applied afterwards, so they can override these.
+func testOptions(w io.Writer, env terminal.Environment, options ...FrameOption) []FrameOption {
+	return append([]FrameOption{
+		WithOutput(w),
+		WithStack(NewStack()),
+		WithClock(newTestClock()),
+		WithTerminal(env),
+	}, options...)
+}
+
+// testRowOptions returns the options for a row that writes to w on its own stack, with env as its
+// terminal and a fake clock for its columns. Any options given are applied afterwards.
+func testRowOptions(w io.Writer, env terminal.Environment, options ...RowOption) []RowOption {
+	return append([]RowOption{
+		WithRowOutput(w),
+		WithRowStack(NewStack()),
+		WithRowTerminal(env),
+		WithColumnOptions(WithClock(newTestClock())),
+	}, options...)
+}
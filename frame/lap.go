@@ -0,0 +1,115 @@
+package frame
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pseudomuto/gooey/ansi"
+)
+
+type (
+	// lapState records the phases of a frame opened with WithLaps
+	lapState struct {
+		mutex   sync.Mutex // guards the phases, since Interrupt may close the frame on another goroutine
+		summary bool
+		name    string    // the heading of the divider that started the current phase
+		start   time.Time // when the current phase started
+		phases  []phase
+	}
+
+	phase struct {
+		name     string
+		duration time.Duration
+	}
+)
+
+// WithLaps splits the frame into phases at each divider. Every divider ends the current phase and shows
+// how long it took, like the elapsed time in the closing border. When summary is true, a breakdown of
+// the phases and their durations is printed before the closing border.
+//
+// Content written before the first divider forms a phase named after the frame, which is left out of
+// the breakdown when the first divider comes straight after the frame opens.
+//
+// Example:
+//
+//	f := frame.Open("Release", frame.WithLaps(true))
+//	f.Divider("compile")
+//	build()
+//	f.Divider("test")
+//	test()
+//	f.Close()
+//
+//	// Shows: ┌── Release ──────────────────┐
+//	//        ├── compile ──────────────────┤
+//	//        ├── test ────────────── (12s) ┤
+//	//        ├─────────────────────────────┤
+//	//        │ compile  12s                │
+//	//        │ test     3.5s               │
+//	//        └──────────────────── (15.5s) ┘
+func WithLaps(summary bool) FrameOption {
+	return func(f *Frame) {
+		f.laps = &lapState{summary: summary}
+	}
+}
+
+// recordLap ends the current phase, starting one named heading, and returns the duration of the phase
+// that ended. Frames without laps return zero.
+func (f *Frame) recordLap(heading string) time.Duration {
+	l := f.laps
+	if l == nil {
+		return 0
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	now := f.clock.Now()
+	duration := now.Sub(l.start)
+	if l.name != "" || len(l.phases) > 0 || duration > time.Millisecond {
+		name := l.name
+		if name == "" && len(l.phases) == 0 {
+			name = f.title
+		}
+
+		l.phases = append(l.phases, phase{name: name, duration: duration})
+	}
+
+	l.name, l.start = heading, now
+	return duration
+}
+
+// emitLapSummary ends the last phase and, when a summary was requested, writes the breakdown of phases
+func (f *Frame) emitLapSummary() {
+	l := f.laps
+	if l == nil {
+		return
+	}
+
+	f.recordLap("")
+	l.mutex.Lock()
+	phases := slices.Clone(l.phases)
+	l.mutex.Unlock()
+
+	if !l.summary || len(phases) == 0 {
+		return
+	}
+
+	width := 0
+	for _, p := range phases {
+		width = max(width, strlen(p.name))
+	}
+
+	ctx := f.renderContext()
+	var out strings.Builder
+	out.WriteString(ctx.line(f.renderer.Divider(ctx, "")) + "\n")
+	for _, p := range phases {
+		name := p.name + strings.Repeat(" ", width-strlen(p.name))
+		duration := ansi.BrightBlack.Colorize(p.duration.Round(time.Millisecond).String())
+		out.WriteString(f.formatContentLine(fmt.Sprintf("%s  %s", name, duration)) + "\n")
+	}
+
+	f.emit(out.String())
+}
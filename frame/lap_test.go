@@ -0,0 +1,112 @@
+package frame_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pseudomuto/gooey/ci"
+	. "github.com/pseudomuto/gooey/frame"
+	"github.com/pseudomuto/gooey/terminal"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFrameLaps(t *testing.T) {
+	tests := []struct {
+		name     string
+		style    FrameStyle
+		summary  bool
+		expected []string
+	}{
+		{
+			name:  "box",
+			style: Box,
+			expected: []string{
+				"┌── Release ───────────────────────┐",
+				"│ preparing                        │",
+				"├── compile ────────────── (500ms) ┤",
+				"├── test ─────────────────── (12s) ┤",
+				"└─────────────────────────── (16s) ┘",
+			},
+		},
+		{
+			name:    "box with summary",
+			style:   Box,
+			summary: true,
+			expected: []string{
+				"┌── Release ───────────────────────┐",
+				"│ preparing                        │",
+				"├── compile ────────────── (500ms) ┤",
+				"├── test ─────────────────── (12s) ┤",
+				"├──────────────────────────────────┤",
+				"│ Release  500ms                   │",
+				"│ compile  12s                     │",
+				"│ test     3.5s                    │",
+				"└─────────────────────────── (16s) ┘",
+			},
+		},
+		{
+			name:  "bracket",
+			style: Bracket,
+			expected: []string{
+				"┌── Release ",
+				"│ preparing",
+				"├── compile (500ms) ",
+				"├── test (12s) ",
+				"└── (16s) ",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			c := newTestClock()
+
+			f := Open("Release",
+				WithOutput(&buf),
+				WithStack(NewStack()),
+				WithClock(c),
+				WithStyle(tt.style),
+				WithLaps(tt.summary),
+				WithTerminal(terminal.Environment{Width: 36, TTY: terminal.TTYOff, Color: terminal.ColorNone, CI: ci.None}),
+			)
+			f.Println("preparing")
+			c.Advance(500 * time.Millisecond)
+			f.Divider("compile")
+			c.Advance(12 * time.Second)
+			f.Divider("test")
+			c.Advance(3500 * time.Millisecond)
+			f.Close()
+
+			require.Equal(t, strings.Join(tt.expected, "\n")+"\n", buf.String())
+		})
+	}
+}
+
+func TestFrameLapsSkipEmptyFirstPhase(t *testing.T) {
+	var buf bytes.Buffer
+	c := newTestClock()
+
+	f := Open("Release",
+		WithOutput(&buf),
+		WithStack(NewStack()),
+		WithClock(c),
+		WithLaps(true),
+		WithTerminal(terminal.Environment{Width: 36, TTY: terminal.TTYOff, Color: terminal.ColorNone, CI: ci.None}),
+	)
+	f.Divider("compile")
+	c.Advance(2 * time.Second)
+	f.Close()
+
+	expected := []string{
+		"┌── Release ───────────────────────┐",
+		"├── compile ───────────────────────┤",
+		"├──────────────────────────────────┤",
+		"│ compile  2s                      │",
+		"└──────────────────────────── (2s) ┘",
+		"",
+	}
+	require.Equal(t, strings.Join(expected, "\n"), buf.String())
+}
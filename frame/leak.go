@@ -0,0 +1,98 @@
+package frame
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"runtime"
+	"strings"
+	"sync/atomic"
+)
+
+// DebugEnv is the environment variable that turns on debug mode (see SetDebug) when it's set to a
+// non-empty value.
+const DebugEnv = "GOOEY_DEBUG"
+
+var (
+	debugFrames atomic.Bool
+
+	// packagePath is this package's import path, used to skip its own functions when finding callers
+	packagePath = reflect.TypeOf((*Frame)(nil)).Elem().PkgPath()
+)
+
+func init() {
+	debugFrames.Store(os.Getenv(DebugEnv) != "")
+}
+
+// SetDebug turns debug mode on or off and returns a function that restores the previous setting. In
+// debug mode, frames record where they were opened, and a frame that is still open when an enclosing
+// frame closes reports that location before it's closed as abandoned. Recording the location has a
+// small cost, so it's off unless enabled here or with the GOOEY_DEBUG environment variable.
+//
+// Example:
+//
+//	defer frame.SetDebug(true)()
+//
+//	outer := frame.Open("Deploy")
+//	frame.Open("Migrate") // never closed
+//	outer.Close()
+//
+//	// Shows: │  │ ⚠ frame left open, opened at /src/deploy/main.go:12 │
+//	//        │  └─────────────────────────────────── ⚠ abandoned ┘ │
+//	//        └──────────────────────────────────────────────────────┘
+func SetDebug(enabled bool) (restore func()) {
+	previous := debugFrames.Swap(enabled)
+	return func() {
+		debugFrames.Store(previous)
+	}
+}
+
+// closeInner closes the frames opened on f's stack after f that are still open, innermost first, marking
+// them as abandoned. It reports false when f itself isn't open on the stack.
+func (f *Frame) closeInner() bool {
+	for {
+		inner := f.stack.Current()
+		if inner == nil || !f.stack.contains(f) {
+			return false
+		}
+
+		if inner == f {
+			return true
+		}
+
+		inner.abandon()
+		if f.stack.Current() == inner {
+			// Another goroutine is writing to the inner frame and closed it first; give up rather than spin
+			return false
+		}
+	}
+}
+
+// abandon closes a frame that was left open when an enclosing frame closed, reporting where it was
+// opened in debug mode
+func (f *Frame) abandon() {
+	color := StatusWarning.color()
+	icon := StatusWarning.icon().Colorize(color)
+	if f.openedAt != "" {
+		f.Println("%s frame left open, opened at %s", icon, f.openedAt)
+	}
+
+	f.close(color.Colorize(StatusWarning.icon().String()+" abandoned"), &color, StatusWarning)
+}
+
+// callerLocation returns the file and line of the first caller outside this package, e.g. the code that
+// called Open
+func callerLocation() string {
+	pcs := make([]uintptr, 16)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(2, pcs)])
+	for {
+		caller, more := frames.Next()
+		if !strings.HasPrefix(caller.Function, packagePath+".") {
+			return fmt.Sprintf("%s:%d", caller.File, caller.Line)
+		}
+
+		if !more {
+			return ""
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package frame_test
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/pseudomuto/gooey/ci"
+	. "github.com/pseudomuto/gooey/frame"
+	"github.com/pseudomuto/gooey/terminal"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCloseAbandonsLeakedFrames(t *testing.T) {
+	defer SetDebug(false)()
+
+	var buf bytes.Buffer
+	stack := NewStack()
+	env := terminal.Environment{Width: 40, Height: 24, TTY: terminal.TTYOff, Color: terminal.ColorNone, CI: ci.None}
+	options := testOptions(&buf, env, WithStack(stack))
+
+	outer := Open("Deploy", options...)
+	Open("Migrate", options...)
+	Open("Seed", options...).Println("inserting rows")
+	outer.Close()
+
+	require.Zero(t, stack.Depth())
+
+	// Later frames render at the right depth again
+	next := Open("Verify", options...)
+	next.Close()
+
+	expected := []string{
+		"┌── Deploy ────────────────────────────┐",
+		"│  ┌── Migrate ──────────────────────┐ │",
+		"│  │  ┌── Seed ────────────────────┐ │ │",
+		"│  │  │ inserting rows             │ │ │",
+		"│  │  └─────────────── ⚠ abandoned ┘ │ │",
+		"│  └──────────────────── ⚠ abandoned ┘ │",
+		"└──────────────────────────────────────┘",
+		"┌── Verify ────────────────────────────┐",
+		"└──────────────────────────────────────┘",
+		"",
+	}
+	require.Equal(t, strings.Join(expected, "\n"), buf.String())
+
+	// Closing a frame that's already closed has no effect
+	outer.Close()
+	require.Equal(t, strings.Join(expected, "\n"), buf.String())
+}
+
+func TestCloseReportsLeakedFrameLocationInDebugMode(t *testing.T) {
+	defer SetDebug(true)()
+
+	var buf bytes.Buffer
+	stack := NewStack()
+	env := terminal.Environment{Width: 500, TTY: terminal.TTYOff, Color: terminal.ColorNone, CI: ci.None}
+	options := testOptions(&buf, env, WithStack(stack))
+
+	outer := Open("Deploy", options...)
+	stack.Open("Migrate", options...)
+	outer.Close()
+
+	require.Regexp(t, regexp.MustCompile(`│  │ ⚠ frame left open, opened at .*leak_test\.go:\d+`), buf.String())
+	require.Contains(t, buf.String(), "⚠ abandoned ┘ │")
+}
@@ -0,0 +1,201 @@
+package frame
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/pseudomuto/gooey/ansi"
+)
+
+const (
+	// LevelDebug is for detail that's only useful when diagnosing a problem
+	LevelDebug Level = iota
+	// LevelInfo is for progress and results
+	LevelInfo
+	// LevelWarn is for problems that don't stop the work
+	LevelWarn
+	// LevelError is for failures
+	LevelError
+)
+
+const (
+	// TimestampNone leaves log lines without a timestamp
+	TimestampNone TimestampMode = iota
+	// TimestampRelative prefixes log lines with the time since the frame opened, e.g. +1.25s
+	TimestampRelative
+	// TimestampAbsolute prefixes log lines with the time of day, e.g. 14:03:27
+	TimestampAbsolute
+)
+
+type (
+	// Level is the severity of a line written with Frame.Debug, Info, Warn or Error. Lines below a frame's
+	// log level are hidden.
+	Level int
+
+	// TimestampMode controls the timestamp written before log lines. See WithTimestamps.
+	TimestampMode int
+)
+
+var defaultLogLevel atomic.Int64
+
+func init() {
+	defaultLogLevel.Store(int64(LevelInfo))
+}
+
+// SetLogLevel sets the log level of frames that have no level of their own (see WithLogLevel) and returns
+// a function that restores the previous level. It defaults to LevelInfo, so Debug lines are hidden until
+// verbose output is turned on with LevelDebug.
+//
+// Example:
+//
+//	if *verbose {
+//		frame.SetLogLevel(frame.LevelDebug)
+//	}
+func SetLogLevel(level Level) (restore func()) {
+	previous := defaultLogLevel.Swap(int64(level))
+	return func() {
+		defaultLogLevel.Store(previous)
+	}
+}
+
+// WithLogLevel sets the lowest level of log line the frame (and the frames nested inside it) shows,
+// overriding the level set with SetLogLevel.
+//
+// Example:
+//
+//	f := frame.Open("Resolve", frame.WithLogLevel(frame.LevelDebug))
+//	f.Debug("trying mirror %s", mirror) // Shown, even though debug lines are hidden elsewhere
+func WithLogLevel(level Level) FrameOption {
+	return func(f *Frame) {
+		f.logLevel = &level
+	}
+}
+
+// WithTimestamps prefixes the frame's log lines (and those of the frames nested inside it) with a
+// timestamp, either the time since this frame opened or the time of day.
+//
+// Example:
+//
+//	f := frame.Open("Deploy", frame.WithTimestamps(frame.TimestampRelative))
+//	f.Info("rolling out") // Shows: │ +0.00s ℹ rolling out │
+func WithTimestamps(mode TimestampMode) FrameOption {
+	return func(f *Frame) {
+		f.timestamps = &mode
+	}
+}
+
+// Debug writes a line prefixed with a grey ○ when the frame's log level is LevelDebug.
+//
+// Example:
+//
+//	f.Debug("cache key %s", key) // Shows: │ ○ cache key 3f9a… │ (in verbose mode)
+func (f *Frame) Debug(format string, a ...any) {
+	f.log(LevelDebug, format, a...)
+}
+
+// Info writes a line prefixed with a blue ℹ.
+//
+// Example:
+//
+//	f.Info("deployed %s", version) // Shows: │ ℹ deployed v1.4.2 │
+func (f *Frame) Info(format string, a ...any) {
+	f.log(LevelInfo, format, a...)
+}
+
+// Warn writes a line prefixed with a yellow ⚠.
+//
+// Example:
+//
+//	f.Warn("%d pods pending", n) // Shows: │ ⚠ 3 pods pending │
+func (f *Frame) Warn(format string, a ...any) {
+	f.log(LevelWarn, format, a...)
+}
+
+// Error writes a line prefixed with a red ✗.
+//
+// Example:
+//
+//	f.Error("health check failed: %v", err) // Shows: │ ✗ health check failed: timeout │
+func (f *Frame) Error(format string, a ...any) {
+	f.log(LevelError, format, a...)
+}
+
+// String returns the level name.
+func (l Level) String() string {
+	switch l {
+	case LevelDebug:
+		return "debug"
+	case LevelInfo:
+		return "info"
+	case LevelWarn:
+		return "warn"
+	case LevelError:
+		return "error"
+	}
+
+	return fmt.Sprintf("Level(%d)", int(l))
+}
+
+func (l Level) icon() string {
+	switch l {
+	case LevelDebug:
+		return ansi.Circle.Colorize(ansi.BrightBlack)
+	case LevelInfo:
+		return ansi.Info.Colorize(ansi.Blue)
+	case LevelWarn:
+		return ansi.Warning.Colorize(ansi.Yellow)
+	case LevelError:
+		return ansi.CrossMark.Colorize(ansi.Red)
+	}
+
+	return ""
+}
+
+// log writes a line at level, unless the frame's log level hides it
+func (f *Frame) log(level Level, format string, a ...any) {
+	if level < f.logThreshold() {
+		return
+	}
+
+	prefix := level.icon()
+	if timestamp := f.timestamp(); timestamp != "" {
+		prefix = ansi.BrightBlack.Colorize(timestamp) + " " + prefix
+	}
+
+	f.Println("%s %s", prefix, fmt.Sprintf(format, a...))
+}
+
+// logThreshold returns the lowest level of log line shown, inherited from the enclosing frames when the
+// frame wasn't given one
+func (f *Frame) logThreshold() Level {
+	for fr := f; fr != nil; fr = fr.parent {
+		if fr.logLevel != nil {
+			return *fr.logLevel
+		}
+	}
+
+	return Level(defaultLogLevel.Load())
+}
+
+// timestamp returns the timestamp for a log line written now, using the mode of the innermost frame
+// that has one. Relative timestamps are measured from when that frame opened.
+func (f *Frame) timestamp() string {
+	for fr := f; fr != nil; fr = fr.parent {
+		if fr.timestamps == nil {
+			continue
+		}
+
+		switch *fr.timestamps {
+		case TimestampRelative:
+			return fmt.Sprintf("+%.2fs", fr.clock.Since(fr.startTime).Seconds())
+		case TimestampAbsolute:
+			return fr.clock.Now().Format(time.TimeOnly)
+		case TimestampNone:
+		}
+
+		break
+	}
+
+	return ""
+}
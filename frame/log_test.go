@@ -0,0 +1,119 @@
+package frame_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pseudomuto/gooey/ci"
+	"github.com/pseudomuto/gooey/clock"
+	. "github.com/pseudomuto/gooey/frame"
+	"github.com/pseudomuto/gooey/terminal"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFrameLogLines(t *testing.T) {
+	tests := []struct {
+		name     string
+		options  []FrameOption
+		level    Level
+		expected []string
+	}{
+		{
+			name:  "default level hides debug lines",
+			level: LevelInfo,
+			expected: []string{
+				"ℹ deployed v1.4.2",
+				"⚠ 3 pods pending",
+				"✗ health check failed",
+			},
+		},
+		{
+			name:  "verbose",
+			level: LevelDebug,
+			expected: []string{
+				"○ cache key 3f9a",
+				"ℹ deployed v1.4.2",
+				"⚠ 3 pods pending",
+				"✗ health check failed",
+			},
+		},
+		{
+			name:    "frame level overrides default",
+			options: []FrameOption{WithLogLevel(LevelWarn)},
+			level:   LevelDebug,
+			expected: []string{
+				"⚠ 3 pods pending",
+				"✗ health check failed",
+			},
+		},
+		{
+			name:    "relative timestamps",
+			options: []FrameOption{WithTimestamps(TimestampRelative)},
+			level:   LevelInfo,
+			expected: []string{
+				"+1.50s ℹ deployed v1.4.2",
+				"+1.50s ⚠ 3 pods pending",
+				"+1.50s ✗ health check failed",
+			},
+		},
+		{
+			name:    "absolute timestamps",
+			options: []FrameOption{WithTimestamps(TimestampAbsolute)},
+			level:   LevelInfo,
+			expected: []string{
+				"09:30:01 ℹ deployed v1.4.2",
+				"09:30:01 ⚠ 3 pods pending",
+				"09:30:01 ✗ health check failed",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer SetLogLevel(tt.level)()
+
+			var buf bytes.Buffer
+			c := clock.NewFake(time.Date(2024, 1, 1, 9, 29, 59, 500_000_000, time.UTC))
+			options := append([]FrameOption{
+				WithOutput(&buf),
+				WithStack(NewStack()),
+				WithClock(c),
+				WithTerminal(terminal.Environment{Width: 40, TTY: terminal.TTYOff, Color: terminal.ColorNone, CI: ci.None}),
+			}, tt.options...)
+
+			f := Open("Deploy", options...)
+			c.Advance(1500 * time.Millisecond)
+
+			// Nested frames inherit the level and timestamps of the frames around them
+			inner := Open("Rollout", options[:4]...)
+			inner.Debug("cache key %s", "3f9a")
+			inner.Info("deployed %s", "v1.4.2")
+			inner.Warn("%d pods pending", 3)
+			inner.Error("health check failed")
+			inner.Close()
+			f.Close()
+
+			lines := strings.Split(buf.String(), "\n")
+			require.Len(t, lines, len(tt.expected)+5)
+
+			content := lines[2 : len(lines)-3]
+			for i, line := range content {
+				// Strip the edges of both frames to compare the nested frame's content
+				line = strings.TrimSuffix(strings.TrimPrefix(line, "│  │ "), "│ │")
+				content[i] = strings.TrimSpace(line)
+			}
+
+			require.Equal(t, tt.expected, content)
+		})
+	}
+}
+
+func TestLevelString(t *testing.T) {
+	require.Equal(t, "debug", LevelDebug.String())
+	require.Equal(t, "info", LevelInfo.String())
+	require.Equal(t, "warn", LevelWarn.String())
+	require.Equal(t, "error", LevelError.String())
+	require.Equal(t, "Level(9)", Level(9).String())
+}
@@ -0,0 +1,326 @@
+package frame
+
+import (
+	"html"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/pseudomuto/gooey/ansi"
+	"github.com/pseudomuto/gooey/ci"
+	"github.com/pseudomuto/gooey/terminal"
+)
+
+// StepSummaryEnv is the environment variable GitHub Actions sets to the path of the job's step summary
+const StepSummaryEnv = "GITHUB_STEP_SUMMARY"
+
+var (
+	// templateRegex matches {{modifier:text}} formatter markup, like ansi.Formatter does
+	templateRegex = regexp.MustCompile(`\{\{([^:}]+):([^}]*)\}\}`)
+
+	// markdownEmphasis maps formatter styles to the Markdown that wraps text in them
+	markdownEmphasis = map[string][2]string{
+		"bold":          {"**", "**"},
+		"italic":        {"_", "_"},
+		"strikethrough": {"~~", "~~"},
+		"underline":     {"<ins>", "</ins>"},
+	}
+
+	// htmlEmphasis maps formatter styles to the HTML that wraps text in them, for text inside HTML
+	// elements where Markdown isn't rendered
+	htmlEmphasis = map[string][2]string{
+		"bold":          {"<b>", "</b>"},
+		"italic":        {"<i>", "</i>"},
+		"strikethrough": {"<s>", "</s>"},
+		"underline":     {"<ins>", "</ins>"},
+	}
+
+	// markdownEscaper escapes the characters Markdown, and the HTML GitHub allows in it, would interpret
+	// anywhere in a line
+	markdownEscaper = strings.NewReplacer(
+		`\`, `\\`, "`", "\\`", "*", `\*`, "_", `\_`, "[", `\[`, "]", `\]`, "|", `\|`, "~", `\~`,
+		"&", "&amp;", "<", "&lt;", ">", "&gt;",
+	)
+
+	// blockMarkerRegex matches the character that makes Markdown turn a line into a heading, a list item
+	// or the underline of a heading
+	blockMarkerRegex = regexp.MustCompile(`^(\s*\d*)([#=+.)-])`)
+
+	// checklistIcons maps the status glyphs that start result lines (e.g. from spinners) to checklist items
+	checklistIcons = map[string]string{
+		ansi.CheckMark.String(): "- [x] ",
+		ansi.CrossMark.String(): "- [ ] " + ansi.CrossMark.String() + " ",
+		ansi.Warning.String():   "- [ ] " + ansi.Warning.String() + " ",
+	}
+)
+
+type (
+	// markdownRenderer implements FrameRenderer for the Markdown style
+	markdownRenderer struct{}
+
+	// heldLine is the last content line written to a Markdown frame. It's held back until another line,
+	// a divider, a nested frame or the end of the frame means it can no longer be replaced, so in-place
+	// updates only leave their final state. Guarded by the frame's mutex.
+	heldLine struct {
+		text    string
+		held    bool // a line is being held back
+		partial bool // the line hasn't been terminated with a newline yet
+	}
+)
+
+// WithMarkdown renders the frame, and the frames nested inside it, as Markdown written to w instead of
+// drawing borders. It is equivalent to WithOutput(w) followed by WithStyle(Markdown): a top-level frame
+// becomes a heading, nested frames become collapsible <details> blocks, dividers become subheadings or
+// rules, and formatter markup such as {{bold:text}} becomes Markdown emphasis. Result lines starting with
+// ✓, ✗ or ⚠ (e.g. from spinners and SpinGroup tasks) become task checklist items. Any other Markdown or
+// HTML in titles and content, such as from command output, is escaped and shows as written.
+//
+// Lines are held back and written once they can no longer change, so in-place updates from spinners
+// and progress bars only leave their final state. Use OpenStepSummary to write to a GitHub Actions job
+// summary.
+//
+// Example:
+//
+//	summary, err := frame.OpenStepSummary()
+//	if err != nil {
+//		return err
+//	}
+//	defer summary.Close()
+//
+//	f := frame.Open("Release", frame.WithMarkdown(summary))
+//	f.Println("Published {{bold:v1.4.2}}")
+//	f.Close()
+//
+//	// Writes: ## Release
+//	//         Published **v1.4.2**
+//	//
+//	//         _(1.2s)_
+func WithMarkdown(w io.Writer) FrameOption {
+	return func(f *Frame) {
+		f.output = w
+		f.style = Markdown
+		f.renderer = nil
+	}
+}
+
+// OpenStepSummary opens the GitHub Actions step summary of the current job for appending, for use with
+// WithMarkdown. It returns an error when GITHUB_STEP_SUMMARY isn't set, e.g. outside GitHub Actions.
+//
+// Example:
+//
+//	if summary, err := frame.OpenStepSummary(); err == nil {
+//		defer summary.Close()
+//		options = append(options, frame.WithMarkdown(summary))
+//	}
+func OpenStepSummary() (io.WriteCloser, error) {
+	path := os.Getenv(StepSummaryEnv)
+	if path == "" {
+		return nil, errors.Errorf("frame: %s is not set", StepSummaryEnv)
+	}
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, errors.Wrap(err, "opening step summary")
+	}
+
+	return file, nil
+}
+
+// setupMarkdown makes frames opened with the Markdown style, and frames nested inside them, render as
+// Markdown, and configures them for their output, which is a file rather than a terminal
+func (f *Frame) setupMarkdown() {
+	switch {
+	case f.style == Markdown:
+		f.markdown = true
+	case f.parent != nil && f.parent.markdown:
+		f.output = f.parent.output
+		f.markdown = true
+	default:
+		return
+	}
+
+	f.renderer = styles[Markdown]
+	f.env = terminal.Environment{
+		Width: f.env.Width, Height: f.env.Height, TTY: terminal.TTYOff, Color: terminal.ColorNone, CI: ci.None,
+	}
+	f.spinner = nil
+	f.collapse = nil
+	f.tail = nil
+	f.held = new(heldLine)
+}
+
+// holdLines writes content to the frame, holding back its last line. The caller must hold the frame's
+// mutex.
+func (f *Frame) holdLines(content string) {
+	h := f.held
+	endsWithNewline := strings.HasSuffix(content, "\n")
+	for i, line := range strings.Split(strings.TrimSuffix(content, "\n"), "\n") {
+		if i == 0 && h.partial {
+			h.text += line
+			continue
+		}
+
+		f.writeHeldLine()
+		h.text, h.held = line, true
+	}
+
+	h.partial = !endsWithNewline
+}
+
+// replaceHeldLine replaces the line being held back, or holds content when there isn't one
+func (f *Frame) replaceHeldLine(content string) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	f.held.text, f.held.held, f.held.partial = content, true, false
+}
+
+// flushHeldLine writes out the line being held back, if there is one
+func (f *Frame) flushHeldLine() {
+	if f.held == nil {
+		return
+	}
+
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.writeHeldLine()
+}
+
+// writeHeldLine writes out the line being held back, if there is one. The caller must hold the frame's
+// mutex.
+func (f *Frame) writeHeldLine() {
+	h := f.held
+	if !h.held {
+		return
+	}
+
+	f.emit(f.formatContentLine(h.text) + "\n")
+	*h = heldLine{}
+}
+
+func (r *markdownRenderer) Open(ctx RenderContext) string {
+	badge := ansi.StripStyles(ctx.Badge)
+	if ctx.Depth <= 1 {
+		title := "## " + markdownText(ctx.Title)
+		if badge != "" {
+			title += " " + markdownCode(badge)
+		}
+
+		return title
+	}
+
+	// Markdown isn't rendered inside the summary element, so its title is HTML instead
+	title := htmlText(ctx.Title)
+	if badge != "" {
+		title += " <code>" + html.EscapeString(badge) + "</code>"
+	}
+
+	// The blank line lets Markdown inside the block render
+	return "<details>\n<summary>" + title + "</summary>\n"
+}
+
+func (r *markdownRenderer) Close(ctx RenderContext) string {
+	var summary string
+	if text := strings.TrimSpace(ansi.StripStyles(ctx.Status) + closingTiming(ctx.Elapsed)); text != "" {
+		summary = "\n_" + markdownEscaper.Replace(text) + "_"
+	}
+
+	if ctx.Depth <= 1 {
+		return summary
+	}
+
+	return summary + "\n</details>"
+}
+
+func (r *markdownRenderer) Divider(ctx RenderContext, heading string) string {
+	lap := strings.TrimSpace(closingTiming(ctx.Lap))
+	if heading == "" && lap == "" {
+		return "\n---"
+	}
+
+	text := strings.TrimSpace(markdownText(heading) + " " + lap)
+	return "\n" + strings.Repeat("#", min(ctx.Depth+2, 6)) + " " + text
+}
+
+func (r *markdownRenderer) Content(_ RenderContext, line string) string {
+	line = markdownText(line)
+	content := strings.TrimLeft(line, " ")
+	indent := line[:len(line)-len(content)]
+	for icon, item := range checklistIcons {
+		if rest, ok := strings.CutPrefix(content, icon+" "); ok {
+			return indent + item + rest
+		}
+	}
+
+	if content == "" {
+		return ""
+	}
+
+	// A trailing double space keeps consecutive lines from being joined into one paragraph
+	return line + "  "
+}
+
+func (r *markdownRenderer) Inset(RenderContext) int {
+	return 0
+}
+
+func (r *markdownRenderer) Edges(ansi.Color) (string, string) {
+	return "", ""
+}
+
+// markdownText converts formatter markup to Markdown emphasis, drops colours and escapes everything
+// else, so that text such as command output or error messages can't change the document's structure
+func markdownText(s string) string {
+	text := convertMarkup(s, markdownEscaper.Replace, markdownEmphasis)
+	return blockMarkerRegex.ReplaceAllString(text, `$1\$2`)
+}
+
+// htmlText converts formatter markup to HTML emphasis, drops colours and escapes everything else
+func htmlText(s string) string {
+	return convertMarkup(s, html.EscapeString, htmlEmphasis)
+}
+
+// convertMarkup replaces formatter markup in s with the emphasis for its styles, escaping the text in
+// and around it with escape
+func convertMarkup(s string, escape func(string) string, emphasis map[string][2]string) string {
+	var b strings.Builder
+	last := 0
+	for _, match := range templateRegex.FindAllStringSubmatchIndex(s, -1) {
+		markup := s[match[0]:match[1]]
+		text := ansi.Format(markup)
+		if text == markup {
+			// Not markup the formatter recognises, so it's escaped as text
+			continue
+		}
+
+		text = escape(ansi.StripStyles(text))
+		for _, modifier := range strings.Split(strings.ToLower(s[match[2]:match[3]]), "+") {
+			if e, ok := emphasis[strings.TrimSpace(modifier)]; ok && text != "" {
+				text = e[0] + text + e[1]
+			}
+		}
+
+		b.WriteString(escape(ansi.StripStyles(s[last:match[0]])))
+		b.WriteString(text)
+		last = match[1]
+	}
+
+	b.WriteString(escape(ansi.StripStyles(s[last:])))
+	return b.String()
+}
+
+// markdownCode returns s as a Markdown code span, delimited by more backticks than any run inside it
+func markdownCode(s string) string {
+	fence := "`"
+	for strings.Contains(s, fence) {
+		fence += "`"
+	}
+
+	if strings.HasPrefix(s, "`") || strings.HasSuffix(s, "`") {
+		s = " " + s + " "
+	}
+
+	return fence + s + fence
+}
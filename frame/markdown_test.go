@@ -0,0 +1,136 @@
+package frame_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/pseudomuto/gooey/ci"
+	. "github.com/pseudomuto/gooey/frame"
+	"github.com/pseudomuto/gooey/terminal"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarkdownFrames(t *testing.T) {
+	var buf bytes.Buffer
+	stack := NewStack()
+	c := newTestClock()
+
+	f := Open("Release", WithMarkdown(&buf), WithStack(stack), WithClock(c))
+	f.Println("Publishing {{bold:v1.4.2}} to {{italic+cyan:production}}")
+	f.Println("rollout started")
+	f.Divider("Checks")
+
+	// Nested frames render as Markdown too, and replaced lines only leave their final state
+	checks := Open("Health checks", WithStack(stack), WithClock(c))
+	checks.Println("⠋ api")
+	checks.ReplaceLine("✓ api")
+	checks.Println("  ✓ database")
+	_, _ = checks.Write([]byte("\x1b[?25l"))
+	c.Advance(1500 * time.Millisecond)
+	_ = checks.CloseWithError(errors.New("worker unhealthy"))
+
+	f.Divider("")
+	f.Close()
+
+	expected := []string{
+		"## Release",
+		"Publishing **v1.4.2** to _production_  ",
+		"rollout started  ",
+		"",
+		"### Checks",
+		"<details>",
+		"<summary>Health checks</summary>",
+		"",
+		"- [x] api",
+		"  - [x] database",
+		"- [ ] ✗ worker unhealthy",
+		"",
+		"_✗ (1.5s)_",
+		"</details>",
+		"",
+		"---",
+		"",
+		"_(1.5s)_",
+		"",
+	}
+	require.Equal(t, strings.Join(expected, "\n"), buf.String())
+}
+
+func TestMarkdownFramesEscapeContent(t *testing.T) {
+	var buf bytes.Buffer
+	stack := NewStack()
+
+	f := Open("Deploy <main> | *prod*", WithMarkdown(&buf), WithStack(stack), WithClock(newTestClock()))
+	f.SetBadge("`v1` & `v2`")
+	checks := Open("</summary></details> {{bold:a_b}}", WithStack(stack), WithClock(newTestClock()))
+	checks.Println("</details><script>alert(1)</script>")
+	checks.Println("# not a heading, `code` or [link](x)")
+	checks.Println("- not a list")
+	checks.Println("1. not a list either")
+	checks.Close()
+	f.Println("✓ {{italic:*_done_*}}")
+	f.Close()
+
+	expected := []string{
+		"## Deploy &lt;main&gt; \\| \\*prod\\*",
+		"» Deploy &lt;main&gt; \\| \\*prod\\* — \\`v1\\` &amp; \\`v2\\`  ",
+		"<details>",
+		"<summary>&lt;/summary&gt;&lt;/details&gt; <b>a_b</b></summary>",
+		"",
+		"&lt;/details&gt;&lt;script&gt;alert(1)&lt;/script&gt;  ",
+		"\\# not a heading, \\`code\\` or \\[link\\](x)  ",
+		"\\- not a list  ",
+		"1\\. not a list either  ",
+		"",
+		"</details>",
+		"- [x] _\\*\\_done\\_\\*_",
+		"",
+		"",
+	}
+	require.Equal(t, strings.Join(expected, "\n"), buf.String())
+}
+
+func TestOpenStepSummary(t *testing.T) {
+	t.Setenv(StepSummaryEnv, "")
+	_, err := OpenStepSummary()
+	require.EqualError(t, err, "frame: GITHUB_STEP_SUMMARY is not set")
+
+	path := filepath.Join(t.TempDir(), "summary.md")
+	require.NoError(t, os.WriteFile(path, []byte("# Job\n"), 0o600))
+	t.Setenv(StepSummaryEnv, path)
+
+	summary, err := OpenStepSummary()
+	require.NoError(t, err)
+
+	f := Open("Release", WithMarkdown(summary), WithStack(NewStack()))
+	f.Println("done")
+	f.Close()
+	require.NoError(t, summary.Close())
+
+	contents, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(string(contents), "# Job\n## Release\ndone  \n"), string(contents))
+
+	// A summary that can't be opened isn't returned as a typed nil
+	t.Setenv(StepSummaryEnv, t.TempDir())
+	summary, err = OpenStepSummary()
+	require.ErrorContains(t, err, "opening step summary")
+	require.True(t, summary == nil)
+}
+
+func TestMarkdownStyle(t *testing.T) {
+	var buf bytes.Buffer
+	env := terminal.Environment{Width: 30, TTY: terminal.TTYOn, Color: terminal.ColorANSI, CI: ci.None}
+
+	f := Open("Release", testOptions(&buf, env, WithStyle(Markdown))...)
+	f.Println("{{bold:starting}}")
+	f.ReplaceLine("{{bold:done}}")
+	f.Close()
+
+	require.Equal(t, "## Release\n**done**  \n\n", buf.String())
+}
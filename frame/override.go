@@ -0,0 +1,85 @@
+package frame
+
+import (
+	"slices"
+
+	"github.com/pseudomuto/gooey/ansi"
+)
+
+// WithFrameColorOverride recolours every frame on the default stack with color while fn runs, e.g.
+// turning everything red when an error bubbles up. It is equivalent to calling
+// DefaultStack().WithFrameColorOverride; see Stack.WithFrameColorOverride for details.
+//
+// Example:
+//
+//	if err := deploy(); err != nil {
+//		frame.WithFrameColorOverride(ansi.Red, func() {
+//			current.Println("deploy failed: %v", err)
+//			current.CloseWithStatus(frame.StatusFailure)
+//		})
+//	}
+func WithFrameColorOverride(color ansi.Color, fn func()) {
+	defaultStack.WithFrameColorOverride(color, fn)
+}
+
+// WithFrameColorOverride recolours every frame on the stack with color while fn runs. The override
+// applies to everything drawn from now on, including the content lines, dividers and closing borders of
+// frames opened before the call, but lines already written (e.g. their opening borders) keep their
+// colour, so a frame's border isn't left half redrawn. Frames are drawn in their own colours again once
+// fn returns. Frames on other stacks aren't affected.
+//
+// Overrides nest: the most recently started one that's still running wins. It's safe to call from
+// several goroutines, with each call removing only its own override when it returns.
+//
+// Example:
+//
+//	stack := frame.StackFor(logFile)
+//	f := stack.Open("Deploy", frame.WithOutput(logFile))
+//	if err := deploy(f); err != nil {
+//		stack.WithFrameColorOverride(ansi.Red, func() {
+//			f.CloseWithStatus(frame.StatusFailure)
+//		})
+//	}
+func (s *Stack) WithFrameColorOverride(color ansi.Color, fn func()) {
+	override := &color
+	s.setColorOverrides(func(overrides []*ansi.Color) []*ansi.Color {
+		return append(overrides, override)
+	})
+
+	defer s.setColorOverrides(func(overrides []*ansi.Color) []*ansi.Color {
+		return slices.DeleteFunc(overrides, func(c *ansi.Color) bool { return c == override })
+	})
+
+	fn()
+}
+
+// setColorOverrides updates the stack's running colour overrides
+func (s *Stack) setColorOverrides(update func([]*ansi.Color) []*ansi.Color) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.overrides = update(s.overrides)
+}
+
+// frameColor returns the colour to draw f with: the stack's colour override, if one is running, or the
+// frame's own colour
+func (s *Stack) frameColor(f *Frame) ansi.Color {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	if override := s.override(); override != nil {
+		return *override
+	}
+
+	return f.color
+}
+
+// override returns the colour of the latest running override, or nil when there isn't one. The caller
+// must hold the stack's mutex.
+func (s *Stack) override() *ansi.Color {
+	if len(s.overrides) == 0 {
+		return nil
+	}
+
+	return s.overrides[len(s.overrides)-1]
+}
@@ -0,0 +1,107 @@
+package frame_test
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/pseudomuto/gooey/ansi"
+	"github.com/pseudomuto/gooey/ci"
+	. "github.com/pseudomuto/gooey/frame"
+	"github.com/pseudomuto/gooey/terminal"
+	"github.com/stretchr/testify/require"
+)
+
+// overrideTestEnv is the terminal the frames in these tests are opened in
+var overrideTestEnv = terminal.Environment{Width: 20, Height: 24, TTY: terminal.TTYOff, Color: terminal.ColorANSI, CI: ci.None}
+
+func TestWithFrameColorOverride(t *testing.T) {
+	var buf bytes.Buffer
+	stack := NewStack()
+	options := testOptions(&buf, overrideTestEnv, WithColor(ansi.Cyan), WithStack(stack))
+
+	outer := Open("Deploy", options...)
+	stack.WithFrameColorOverride(ansi.Red, func() {
+		inner := Open("Rollback", options...)
+
+		stack.WithFrameColorOverride(ansi.Yellow, func() {
+			inner.Divider("")
+		})
+
+		inner.Close()
+	})
+	outer.Close()
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	require.Len(t, lines, 5)
+
+	// Frames opened inside the override, and the edges of the frames around them, are recoloured
+	require.True(t, strings.HasPrefix(lines[0], ansi.Cyan.String()))
+	require.True(t, strings.HasPrefix(lines[1], ansi.Red.Colorize("│  ")+ansi.Red.String()+"┌──"), lines[1])
+	require.True(t, strings.HasPrefix(lines[2], ansi.Yellow.Colorize("│  ")+ansi.Yellow.String()+"├"), lines[2])
+	require.True(t, strings.HasPrefix(lines[3], ansi.Red.Colorize("│  ")+ansi.Red.String()+"└"), lines[3])
+
+	// The frame's own colour is back once the override returns
+	require.True(t, strings.HasPrefix(lines[4], ansi.Cyan.String()+"└"), lines[4])
+}
+
+func TestWithFrameColorOverrideLeavesWrittenLines(t *testing.T) {
+	var buf bytes.Buffer
+	env := overrideTestEnv
+	env.TTY = terminal.TTYOn
+
+	stack := NewStack()
+	outer := Open("Deploy", testOptions(&buf, env, WithColor(ansi.Cyan), WithStack(stack))...)
+	outer.Println("working")
+	written := buf.String()
+
+	stack.WithFrameColorOverride(ansi.Red, func() {
+		outer.Println("failed")
+	})
+	outer.Close()
+
+	// Nothing already on screen is redrawn, so the frame is never left half recoloured
+	require.True(t, strings.HasPrefix(buf.String(), written))
+	require.NotContains(t, buf.String(), ansi.SaveCursor)
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	require.Len(t, lines, 4)
+	require.True(t, strings.HasPrefix(lines[2], ansi.Red.String()+"│"), lines[2])
+	require.True(t, strings.HasPrefix(lines[3], ansi.Cyan.String()+"└"), lines[3])
+}
+
+func TestWithFrameColorOverrideConcurrently(t *testing.T) {
+	var buf bytes.Buffer
+	stack := NewStack()
+	options := testOptions(&buf, overrideTestEnv, WithColor(ansi.Cyan), WithStack(stack))
+
+	var wg sync.WaitGroup
+	for _, color := range []ansi.Color{ansi.Red, ansi.Green, ansi.Yellow, ansi.Blue} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 100 {
+				stack.WithFrameColorOverride(color, func() {})
+			}
+		}()
+	}
+	wg.Wait()
+
+	// Every override has been removed again
+	Open("Done", options...).Close()
+	require.True(t, strings.HasPrefix(buf.String(), ansi.Cyan.String()+"┌──"), buf.String())
+}
+
+func TestWithFrameColorOverrideOnlyRecoloursItsStack(t *testing.T) {
+	var failed, other bytes.Buffer
+	stack := NewStack()
+
+	stack.WithFrameColorOverride(ansi.Red, func() {
+		Open("Deploy", testOptions(&failed, overrideTestEnv, WithColor(ansi.Cyan), WithStack(stack))...).Close()
+		Open("Lint", testOptions(&other, overrideTestEnv, WithColor(ansi.Cyan))...).Close()
+	})
+
+	require.True(t, strings.HasPrefix(failed.String(), ansi.Red.String()+"┌──"), failed.String())
+	require.True(t, strings.HasPrefix(other.String(), ansi.Cyan.String()+"┌──"), other.String())
+}
@@ -2,6 +2,7 @@ package frame
 
 import (
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/pseudomuto/gooey/ansi"
@@ -18,456 +19,442 @@ const (
 	boxVertical    = "│"
 	boxTee         = "├"
 	boxTeeRight    = "┤"
-)
 
-// frameDimensions holds calculated dimensions for frame rendering
-type frameDimensions struct {
-	depth              int
-	parentPrefixWidth  int
-	rightBorderWidth   int
-	outerFrameBorders  int
-	parentBorderSpaces int
-	availableWidth     int
-}
+	// minFrameWidth is the narrowest width a frame is rendered at, regardless of the terminal width
+	minFrameWidth = 10
+)
 
-// calculateFrameDimensions computes the dimensions needed for frame rendering
-func calculateFrameDimensions(termWidth int, depth int) frameDimensions {
-	parentPrefixWidth := 0
-	if depth > 1 {
-		parentPrefixWidth = (depth - 1) * strlen(frameVerticalPrefix)
+var (
+	styles = map[FrameStyle]FrameRenderer{
+		Box:      &boxRenderer{chars: lightChars},
+		Bracket:  new(bracketRenderer),
+		Rounded:  &boxRenderer{chars: roundedChars},
+		Double:   &boxRenderer{chars: doubleChars},
+		Heavy:    &boxRenderer{chars: heavyChars},
+		ASCII:    &boxRenderer{chars: asciiChars},
+		Markdown: new(markdownRenderer),
 	}
+	stylesMutex sync.RWMutex
 
-	rightBorderWidth := strlen(boxVertical)
-	outerFrameBorders := max(depth-1, 0) * rightBorderWidth
-	parentBorderSpaces := max(depth-1, 0) * 1 // 1 space per parent border
-
-	availableWidth := max(termWidth-parentPrefixWidth-outerFrameBorders-parentBorderSpaces, 10)
-
-	return frameDimensions{
-		depth:              depth,
-		parentPrefixWidth:  parentPrefixWidth,
-		rightBorderWidth:   rightBorderWidth,
-		outerFrameBorders:  outerFrameBorders,
-		parentBorderSpaces: parentBorderSpaces,
-		availableWidth:     availableWidth,
+	lightChars = boxChars{
+		topLeft: boxTopLeft, topRight: boxTopRight, bottomLeft: boxBottomLeft, bottomRight: boxBottomRight,
+		horizontal: boxHorizontal, vertical: boxVertical, tee: boxTee, teeRight: boxTeeRight,
 	}
-}
-
-// renderParentPrefixes renders the prefixes for parent frames
-func renderParentPrefixes(result *strings.Builder, depth int) {
-	if depth > 1 {
-		parentFrameColors := stack.frameColors(depth - 1)
-		for i := 0; i < depth-1; i++ {
-			var prefixColor ansi.Color
-			if i < len(parentFrameColors) {
-				prefixColor = parentFrameColors[i]
-			} else {
-				prefixColor = defaultFrameColor // fallback
-			}
-			result.WriteString(prefixColor.Sprint(frameVerticalPrefix))
-		}
+	roundedChars = boxChars{
+		topLeft: "╭", topRight: "╮", bottomLeft: "╰", bottomRight: "╯",
+		horizontal: boxHorizontal, vertical: boxVertical, tee: boxTee, teeRight: boxTeeRight,
 	}
-}
-
-// renderParentBorders renders the right borders for parent frames
-func renderParentBorders(result *strings.Builder, depth int) {
-	parentFrameColors := stack.frameColors(depth - 1)
-	for i := depth - 2; i >= 0; i-- {
-		var borderColor ansi.Color
-		if i < len(parentFrameColors) {
-			borderColor = parentFrameColors[i]
-		} else {
-			borderColor = defaultFrameColor // fallback
-		}
-		result.WriteString(" " + borderColor.Sprint(boxVertical))
+	doubleChars = boxChars{
+		topLeft: "╔", topRight: "╗", bottomLeft: "╚", bottomRight: "╝",
+		horizontal: "═", vertical: "║", tee: "╠", teeRight: "╣",
 	}
-}
+	heavyChars = boxChars{
+		topLeft: "┏", topRight: "┓", bottomLeft: "┗", bottomRight: "┛",
+		horizontal: "━", vertical: "┃", tee: "┣", teeRight: "┫",
+	}
+	asciiChars = boxChars{
+		topLeft: "+", topRight: "+", bottomLeft: "+", bottomRight: "+",
+		horizontal: "-", vertical: "|", tee: "+", teeRight: "+",
+	}
+)
 
 type (
-	// frameRenderer defines the interface for different frame rendering styles
-	frameRenderer interface {
-		formatContentLine(content string, color ansi.Color) string
-		formatContentLineWithDepth(content string, color ansi.Color, frameDepth int) string
-		openFrame(title string, color ansi.Color) string
-		closeFrame(elapsed time.Duration, color ansi.Color) string
-		createDivider(text string, color ansi.Color) string
+	// FrameRenderer draws a frame's borders and content lines. Open, Close, Divider and Content each
+	// return a single line for the frame being rendered, without a trailing newline. The frame adds the
+	// edges of its parent frames around every line, so renderers only ever draw their own frame, and
+	// frames of different styles can be nested freely.
+	//
+	// Renderers whose content lines aren't enclosed like nested frames can also implement
+	// Inset(ctx RenderContext) int, returning the number of columns of a content line taken up by their
+	// borders and padding. Content is fitted to the rest of ctx.Width, which is what Frame.ContentWidth
+	// reports. Without it, a content line is assumed to take up the width of Edges plus the padding.
+	//
+	// Example:
+	//
+	//	type arrowRenderer struct{}
+	//
+	//	func (arrowRenderer) Open(ctx frame.RenderContext) string {
+	//		return ctx.Color.Sprint(">> ") + ctx.Title
+	//	}
+	//
+	//	func (arrowRenderer) Close(ctx frame.RenderContext) string {
+	//		return ctx.Color.Sprint("<< ") + ctx.Status
+	//	}
+	//
+	//	func (arrowRenderer) Divider(ctx frame.RenderContext, heading string) string {
+	//		return ctx.Color.Sprint("-- ") + heading
+	//	}
+	//
+	//	func (arrowRenderer) Content(ctx frame.RenderContext, line string) string {
+	//		return ctx.Color.Sprint("   ") + line
+	//	}
+	//
+	//	func (arrowRenderer) Edges(color ansi.Color) (string, string) {
+	//		return color.Sprint("   "), ""
+	//	}
+	//
+	//	f := frame.Open("Deploy", frame.WithRenderer(arrowRenderer{}))
+	FrameRenderer interface {
+		// Open returns the opening line of the frame, typically a top border containing ctx.Title.
+		Open(ctx RenderContext) string
+		// Close returns the closing line of the frame. ctx.Elapsed is the frame's total duration and
+		// ctx.Status holds an optional (possibly coloured) status, such as an interrupted marker.
+		Close(ctx RenderContext) string
+		// Divider returns a line separating sections of the frame, with an optional heading.
+		Divider(ctx RenderContext, heading string) string
+		// Content returns a line of content inside the frame.
+		Content(ctx RenderContext, line string) string
+		// Edges returns what this frame draws to the left and right of every line of a frame nested inside
+		// it. The right edge may be empty for styles without a right border.
+		Edges(color ansi.Color) (left, right string)
 	}
 
-	// boxRenderer implements frameRenderer for Box style frames
-	boxRenderer struct {
-		termWidth int
+	// insetter is implemented by renderers that report how many columns of a content line their borders
+	// and padding take up, see FrameRenderer
+	insetter interface {
+		Inset(ctx RenderContext) int
 	}
 
-	// bracketRenderer implements frameRenderer for Bracket style frames
-	bracketRenderer struct {
-		termWidth int
+	// RenderContext describes the frame being rendered and where it sits in the frame stack.
+	RenderContext struct {
+		// Title is the frame title. Template syntax such as {{bold:text}} is not yet formatted.
+		Title string
+		// Badge is an optional status shown at the right of the opening line, such as "3/7 services".
+		Badge string
+		// Color is the frame's colour, taking any colour override into account.
+		Color ansi.Color
+		// Depth is the 1-based nesting depth of the frame; a top-level frame has depth 1.
+		Depth int
+		// Parents lists the frames enclosing this one, outermost first.
+		Parents []ParentFrame
+		// Width is the number of columns available to this frame once the edges of its parents have been
+		// drawn. Lines should not be wider than Width.
+		Width int
+		// Elapsed is the time since the frame was opened.
+		Elapsed time.Duration
+		// Lap is the duration of the phase ended by a divider, for frames opened with WithLaps. It's zero
+		// when rendering anything other than a divider.
+		Lap time.Duration
+		// Status is an optional status shown when the frame is closed.
+		Status string
+		// Align is the alignment of content lines within the frame.
+		Align Alignment
+		// TitleAlign is the alignment of the title and divider headings within their borders.
+		TitleAlign Alignment
+		// Padding is the number of blank columns added between the frame's borders and its content, on
+		// top of the space renderers leave after the left border.
+		Padding int
 	}
-)
-
-func (r *boxRenderer) formatContentLine(content string, color ansi.Color) string {
-	// Use the current stack depth for backward compatibility
-	return r.formatContentLineWithDepth(content, color, stack.depth())
-}
 
-func (r *boxRenderer) formatContentLineWithDepth(content string, color ansi.Color, frameDepth int) string {
-	// Use the specific frame depth instead of total stack depth
-	depth := frameDepth
-
-	// Calculate total prefix width: parent prefixes + current frame left border
-	// Parent frames use frameVerticalPrefix ("│  "), current frame uses boxVertical + " " ("│ ")
-	var totalPrefixWidth int
-	if depth > 1 {
-		totalPrefixWidth = (depth-1)*strlen(frameVerticalPrefix) + strlen(boxVertical+" ")
-	} else {
-		totalPrefixWidth = strlen(boxVertical + " ")
+	// ParentFrame describes a frame that encloses the frame being rendered.
+	ParentFrame struct {
+		Color    ansi.Color
+		Renderer FrameRenderer
+		// Padding is the parent's horizontal padding, which is added inside its edges.
+		Padding int
 	}
 
-	// Right border is just the current frame's right border
-	rightBorderWidth := strlen(boxVertical)
-
-	// For nested frames, we need to account for the outer frame's right border
-	// Each level of nesting reduces available width by the outer frame's right border
-	outerFrameBorders := max(depth-1, 0) * rightBorderWidth
-
-	// Account for spaces before parent borders - each parent border gets a space prefix
-	parentBorderSpaces := max(depth-1, 0) * 1 // 1 space per parent border
-
-	// Available content width accounts for all prefixes, right borders, and border spaces
-	availableContentWidth := max(r.termWidth-totalPrefixWidth-rightBorderWidth-outerFrameBorders-parentBorderSpaces, 1)
-
-	// Pre-process content to handle ANSI template syntax (e.g., {{bold+cyan:work}})
-	// This ensures width calculations are based on the final rendered content
-	processedContent := content
-
-	if strings.Contains(content, "{{") && strings.Contains(content, "}}") {
-		// Use QuickFormat to process template syntax and get the actual ANSI result
-		processedContent = ansi.Format(content)
+	// boxChars holds the characters used to draw a fully enclosed frame
+	boxChars struct {
+		topLeft, topRight       string
+		bottomLeft, bottomRight string
+		horizontal, vertical    string
+		tee, teeRight           string
 	}
 
-	// Truncate content if too long - need to handle ANSI sequences properly
-	if strlen(processedContent) > availableContentWidth {
-		// For content with ANSI codes, we need to truncate based on printable width
-		processedContent = term.TruncateString(processedContent, availableContentWidth-3) + "..."
+	// boxRenderer implements FrameRenderer for fully enclosed frames (Box, Rounded, Double, Heavy and ASCII)
+	boxRenderer struct {
+		chars boxChars
 	}
 
-	// Pad content to exactly fill the available width
-	// We need to be careful with ANSI sequences - the padding should be based on printable width
-	contentPrintableWidth := strlen(processedContent)
-	padding := max(availableContentWidth-contentPrintableWidth, 0)
-	paddedContent := processedContent + strings.Repeat(" ", padding)
-
-	// Get the colors of all frames in the stack up to current depth
-	frameColors := stack.frameColors(depth)
-
-	// Build the full line
-	var result strings.Builder
-
-	// Add all frame prefixes (including current frame's left border)
-	// The last prefix becomes the current frame's left border
-	for i := range depth {
-		// Use the appropriate frame's color for each prefix
-		var prefixColor ansi.Color
-		if i < len(frameColors) {
-			prefixColor = frameColors[i]
-		} else {
-			prefixColor = color // fallback to current frame color
-		}
+	// bracketRenderer implements FrameRenderer for Bracket style frames
+	bracketRenderer struct{}
+)
 
-		if i == depth-1 {
-			// Current frame - use left border
-			result.WriteString(prefixColor.Sprint(boxVertical + " "))
-		} else {
-			// Parent frame - use vertical continuation
-			result.WriteString(prefixColor.Sprint(frameVerticalPrefix))
-		}
+// RegisterStyle registers a renderer as a frame style and returns the style so it can be used with
+// WithStyle or as the default style of an application.
+//
+// Example:
+//
+//	var Arrow = frame.RegisterStyle(arrowRenderer{})
+//
+//	f := frame.Open("Deploy", frame.WithStyle(Arrow))
+func RegisterStyle(renderer FrameRenderer) FrameStyle {
+	stylesMutex.Lock()
+	defer stylesMutex.Unlock()
+
+	var style FrameStyle
+	for s := range styles {
+		style = max(style, s+1)
 	}
 
-	// Add the padded content
-	result.WriteString(paddedContent)
+	styles[style] = renderer
+	return style
+}
 
-	// Add right borders for current frame and all parent frames
-	// Each frame's content should show ALL right borders from innermost to outermost
-	// We only need colors up to this frame's depth
-	allFrameColors := stack.frameColors(depth)
+// styleRenderer returns the renderer for a registered style, falling back to Box for unknown styles
+func styleRenderer(style FrameStyle) FrameRenderer {
+	stylesMutex.RLock()
+	defer stylesMutex.RUnlock()
 
-	// Show current frame border first
-	if depth > 0 {
-		currentFrameColor := color
-		if depth-1 < len(allFrameColors) {
-			currentFrameColor = allFrameColors[depth-1]
-		}
-		result.WriteString(currentFrameColor.Sprint(boxVertical))
-	}
-
-	// Then show parent frames from immediate parent to outermost parent
-	// For frame at depth 3: immediate parent (index 1), then outermost parent (index 0)
-	for i := depth - 2; i >= 0; i-- {
-		// Current logic goes: 1, 0 (immediate parent, outermost) - this is CORRECT
-		// But somehow it's still showing reverse order...
-		var borderColor ansi.Color
-		if i < len(allFrameColors) {
-			borderColor = allFrameColors[i]
-		} else {
-			borderColor = color // fallback
-		}
-		result.WriteString(" " + borderColor.Sprint(boxVertical))
+	if renderer, ok := styles[style]; ok {
+		return renderer
 	}
 
-	return result.String()
+	return styles[Box]
 }
 
-func (r *boxRenderer) openFrame(title string, color ansi.Color) string {
-	depth := stack.depth()
-	dims := calculateFrameDimensions(r.termWidth, depth)
-
-	// Pre-process title to handle ANSI template syntax (e.g., {{unicorn:}})
-	// This ensures width calculations are based on the final rendered content
-	processedTitle := title
-	if strings.Contains(title, "{{") && strings.Contains(title, "}}") {
-		processedTitle = ansi.Format(title)
-	}
-
-	// Create title with spaces but without color applied yet
-	titleWithSpaces := " " + processedTitle + " "
-	if strlen(titleWithSpaces) > dims.availableWidth-4 {
-		// Truncate title if too long
-		maxTitleLen := dims.availableWidth - 6 // Space for borders and spaces
-		if maxTitleLen > 0 {
-			processedTitle = processedTitle[:maxTitleLen] + "..."
-			titleWithSpaces = " " + processedTitle + " "
-		} else {
-			titleWithSpaces = ""
+// edges returns the combined left and right edges of the parent frames, including their padding. Left
+// edges are ordered outermost first and right edges innermost first, so each parent encloses the frames
+// inside it.
+func (ctx RenderContext) edges() (left, right string) {
+	var l, r strings.Builder
+	for i, parent := range ctx.Parents {
+		pl, _ := parent.Renderer.Edges(parent.Color)
+		l.WriteString(pl + strings.Repeat(" ", parent.Padding))
+
+		inner := ctx.Parents[len(ctx.Parents)-1-i]
+		if _, pr := inner.Renderer.Edges(inner.Color); pr != "" {
+			r.WriteString(strings.Repeat(" ", inner.Padding) + pr)
 		}
 	}
 
-	// Build the line
-	var result strings.Builder
-
-	// Add parent frame prefixes with their original colors
-	renderParentPrefixes(&result, depth)
+	return l.String(), r.String()
+}
 
-	// Add current frame's top border with title in default color and borders in frame color
-	horizontalFill := max(dims.availableWidth-4-strlen(titleWithSpaces), 0)
-	leftBorder := color.Sprint(boxTopLeft + strings.Repeat(boxHorizontal, 2))
-	rightBorder := color.Sprint(strings.Repeat(boxHorizontal, horizontalFill) + boxTopRight)
+// line surrounds a line rendered for this frame with the edges of its parents. When a parent draws a
+// right edge, the line is fitted to the available width so that the parent's edge lines up.
+func (ctx RenderContext) line(segment string) string {
+	left, right := ctx.edges()
+	if right != "" {
+		segment = fitWidth(segment, ctx.Width)
+	}
 
-	result.WriteString(leftBorder)
-	result.WriteString(titleWithSpaces) // Title in default color
-	result.WriteString(rightBorder)
+	return left + segment + right
+}
 
-	// Add parent frame right borders
-	renderParentBorders(&result, depth)
+// inset returns the number of columns of a content line taken up by the frame's borders and padding
+func (ctx RenderContext) inset(r FrameRenderer) int {
+	if i, ok := r.(insetter); ok {
+		return i.Inset(ctx)
+	}
 
-	result.WriteString("\n")
-	return result.String()
+	// Other renderers are assumed to enclose their content like they enclose nested frames
+	left, right := r.Edges(ctx.Color)
+	return strlen(left) + strlen(right) + 2*ctx.Padding
 }
 
-func (r *boxRenderer) closeFrame(elapsed time.Duration, color ansi.Color) string {
-	depth := stack.depth()
-	dims := calculateFrameDimensions(r.termWidth, depth)
+func (r *boxRenderer) Open(ctx RenderContext) string {
+	c := r.chars
 
-	// Create bottom border with timing
-	var timingText string
-	if elapsed > time.Millisecond {
-		timingText = " (" + elapsed.Round(time.Millisecond).String() + ") "
+	// The badge sits before the right corner, taking at most half of the border
+	var badge string
+	if text := fitHeading(ctx.Badge, ctx.Width/2); text != "" {
+		badge = text + ctx.Color.Sprint(c.horizontal)
 	}
 
-	// Build the line
-	var result strings.Builder
-
-	// Add parent frame prefixes with their original colors
-	renderParentPrefixes(&result, depth)
-
-	// Add current frame's bottom border
-	horizontalFill := max(dims.availableWidth-4-strlen(timingText), 0)
-	bottomBorder := boxBottomLeft + strings.Repeat(boxHorizontal, 2) + strings.Repeat(boxHorizontal, horizontalFill) + timingText + boxBottomRight
-	result.WriteString(color.Sprint(bottomBorder))
+	title := fitHeading(ctx.Title, ctx.Width-4-strlen(badge))
 
-	// Add parent frame right borders
-	renderParentBorders(&result, depth)
+	// Top border with title and badge in default color and borders in frame color
+	before, after := headingFill(ctx.Width-2-strlen(title)-strlen(badge), ctx.TitleAlign)
+	leftBorder := ctx.Color.Sprint(c.topLeft + strings.Repeat(c.horizontal, before))
+	fill := ctx.Color.Sprint(strings.Repeat(c.horizontal, after))
 
-	result.WriteString("\n")
-	return result.String()
+	return leftBorder + title + fill + badge + ctx.Color.Sprint(c.topRight)
 }
 
-func (r *boxRenderer) createDivider(text string, color ansi.Color) string {
-	depth := stack.depth()
-	dims := calculateFrameDimensions(r.termWidth, depth)
+func (r *boxRenderer) Close(ctx RenderContext) string {
+	c := r.chars
+	timingText := closingTiming(ctx.Elapsed)
 
-	// Create divider text with spaces but without color applied yet
-	var textWithSpaces string
-	if text != "" {
-		textWithSpaces = " " + text + " "
+	// Bottom border with timing. The status keeps its own colours, so the border is split around it.
+	if ctx.Status == "" {
+		horizontalFill := max(ctx.Width-4-strlen(timingText), 0)
+		bottomBorder := c.bottomLeft + strings.Repeat(c.horizontal, 2+horizontalFill) + timingText + c.bottomRight
+		return ctx.Color.Sprint(bottomBorder)
 	}
 
-	if strlen(textWithSpaces) > dims.availableWidth-4 {
-		// Truncate text if too long
-		maxTextLen := dims.availableWidth - 6 // Space for borders and spaces
-		if maxTextLen > 0 {
-			text = text[:maxTextLen] + "..."
-			textWithSpaces = " " + text + " "
-		} else {
-			textWithSpaces = ""
-		}
+	statusText := " " + ctx.Status
+	tail := timingText
+	if tail == "" {
+		tail = " "
 	}
 
-	// Build the line
-	var result strings.Builder
+	horizontalFill := max(ctx.Width-4-strlen(statusText)-strlen(tail), 0)
+	return ctx.Color.Sprint(c.bottomLeft+strings.Repeat(c.horizontal, 2+horizontalFill)) +
+		statusText +
+		ctx.Color.Sprint(tail+c.bottomRight)
+}
 
-	// Add parent frame prefixes with their original colors
-	renderParentPrefixes(&result, depth)
+func (r *boxRenderer) Divider(ctx RenderContext, heading string) string {
+	c := r.chars
+	lap := closingTiming(ctx.Lap)
+	text := fitHeading(heading, ctx.Width-4-strlen(lap))
 
-	// Add current frame's divider with text in default color and borders in frame color
-	rightFill := max(dims.availableWidth-4-strlen(textWithSpaces), 0)
-	leftBorder := color.Sprint(boxTee + strings.Repeat(boxHorizontal, 2))
-	rightBorder := color.Sprint(strings.Repeat(boxHorizontal, rightFill) + boxTeeRight)
+	// Divider with text in default color and borders (and any lap time before the right one) in frame color
+	before, after := headingFill(ctx.Width-2-strlen(text)-strlen(lap), ctx.TitleAlign)
+	leftBorder := ctx.Color.Sprint(c.tee + strings.Repeat(c.horizontal, before))
+	rightBorder := ctx.Color.Sprint(strings.Repeat(c.horizontal, after) + lap + c.teeRight)
 
-	result.WriteString(leftBorder)
-	result.WriteString(textWithSpaces) // Text in default color
-	result.WriteString(rightBorder)
+	return leftBorder + text + rightBorder
+}
 
-	// Add parent frame right borders
-	renderParentBorders(&result, depth)
+func (r *boxRenderer) Content(ctx RenderContext, line string) string {
+	padding := strings.Repeat(" ", ctx.Padding)
+	availableContentWidth := max(ctx.Width-r.Inset(ctx), 1)
 
-	result.WriteString("\n")
-	return result.String()
+	return ctx.Color.Sprint(r.chars.vertical+" ") + padding +
+		alignWidth(formatTemplate(line), availableContentWidth, ctx.Align) +
+		padding + ctx.Color.Sprint(r.chars.vertical)
 }
 
-func (r *bracketRenderer) formatContentLine(content string, color ansi.Color) string {
-	// Use the current stack depth for backward compatibility
-	return r.formatContentLineWithDepth(content, color, stack.depth())
+func (r *boxRenderer) Inset(ctx RenderContext) int {
+	// Left border plus space, and the right border, with the padding inside them
+	return 3 + 2*ctx.Padding
 }
 
-func (r *bracketRenderer) formatContentLineWithDepth(content string, color ansi.Color, frameDepth int) string {
-	// Use the specific frame depth instead of total stack depth
-	depth := frameDepth
-
-	// Pre-process content to handle ANSI template syntax (e.g., {{bold+cyan:work}})
-	// This ensures width calculations are based on the final rendered content
-	processedContent := content
-	if strings.Contains(content, "{{") && strings.Contains(content, "}}") {
-		// Use QuickFormat to process template syntax and get the actual ANSI result
-		processedContent = ansi.Format(content)
-	}
-
-	// Get the colors of all frames in the stack up to current depth
-	frameColors := stack.frameColors(depth)
-
-	// Build the full line
-	var result strings.Builder
-
-	// Add all frame prefixes (including current frame's left border)
-	// The last prefix becomes the current frame's left border
-	for i := range depth {
-		// Use the appropriate frame's color for each prefix
-		var prefixColor ansi.Color
-		if i < len(frameColors) {
-			prefixColor = frameColors[i]
-		} else {
-			prefixColor = color // fallback to current frame color
-		}
+func (r *boxRenderer) Edges(color ansi.Color) (string, string) {
+	return color.Sprint(r.chars.vertical + "  "), " " + color.Sprint(r.chars.vertical)
+}
 
-		if i == depth-1 {
-			// Current frame - use left border
-			result.WriteString(prefixColor.Sprint(boxVertical + " "))
-		} else {
-			// Parent frame - use vertical continuation
-			result.WriteString(prefixColor.Sprint(frameVerticalPrefix))
-		}
+func (r *bracketRenderer) Open(ctx RenderContext) string {
+	// Only the left border without horizontal fill or right border, followed by the badge
+	var badge string
+	if text := fitHeading(ctx.Badge, ctx.Width/2); text != "" {
+		badge = ctx.Color.Sprint(strings.Repeat(boxHorizontal, 2)) + text
 	}
 
-	// Add the content without any padding or right borders
-	result.WriteString(processedContent)
+	title := fitHeading(ctx.Title, ctx.Width-4-strlen(badge))
+	before, _ := headingFill(ctx.Width-1-strlen(title)-strlen(badge), ctx.TitleAlign)
+	return ctx.Color.Sprint(boxTopLeft+strings.Repeat(boxHorizontal, before)) + title + badge
+}
+
+func (r *bracketRenderer) Close(ctx RenderContext) string {
+	var status string
+	if ctx.Status != "" {
+		status = " " + ctx.Status
+	}
 
-	return result.String()
+	// Timing in default color
+	return ctx.Color.Sprint(boxBottomLeft+strings.Repeat(boxHorizontal, 2)) + status + closingTiming(ctx.Elapsed)
 }
 
-func (r *bracketRenderer) openFrame(title string, color ansi.Color) string {
-	depth := stack.depth()
-	dims := calculateFrameDimensions(r.termWidth, depth)
-
-	// Pre-process title to handle ANSI template syntax (e.g., {{unicorn:}})
-	// This ensures width calculations are based on the final rendered content
-	processedTitle := title
-	if strings.Contains(title, "{{") && strings.Contains(title, "}}") {
-		processedTitle = ansi.Format(title)
-	}
-
-	// Create title with spaces but without color applied yet
-	titleWithSpaces := " " + processedTitle + " "
-	if strlen(titleWithSpaces) > dims.availableWidth-4 {
-		// Truncate title if too long
-		maxTitleLen := dims.availableWidth - 6 // Space for borders and spaces
-		if maxTitleLen > 0 {
-			processedTitle = processedTitle[:maxTitleLen] + "..."
-			titleWithSpaces = " " + processedTitle + " "
-		} else {
-			titleWithSpaces = ""
-		}
+func (r *bracketRenderer) Divider(ctx RenderContext, heading string) string {
+	var text string
+	if heading != "" {
+		text = " " + heading + " "
+	}
+
+	// Text and any lap time in default color
+	lap := closingTiming(ctx.Lap)
+	if text != "" {
+		lap = strings.TrimPrefix(lap, " ")
 	}
 
-	// Build the line
-	var result strings.Builder
+	before, _ := headingFill(ctx.Width-1-strlen(text)-strlen(lap), ctx.TitleAlign)
+	return ctx.Color.Sprint(boxTee+strings.Repeat(boxHorizontal, before)) + text + lap
+}
 
-	// Add parent frame prefixes with their original colors
-	renderParentPrefixes(&result, depth)
+func (r *bracketRenderer) Content(ctx RenderContext, line string) string {
+	// The content without right borders, so only centred and right-aligned lines are padded
+	prefix := ctx.Color.Sprint(boxVertical+" ") + strings.Repeat(" ", ctx.Padding)
+	if ctx.Align == AlignLeft {
+		return prefix + formatTemplate(line)
+	}
 
-	// Add current frame's top border with title in default color and borders in frame color
-	// For bracket style, we only add the left border without horizontal fill or right border
-	leftBorder := color.Sprint(boxTopLeft + strings.Repeat(boxHorizontal, 2))
+	availableContentWidth := max(ctx.Width-r.Inset(ctx), 1)
+	return prefix + strings.TrimRight(alignWidth(formatTemplate(line), availableContentWidth, ctx.Align), " ")
+}
 
-	result.WriteString(leftBorder)
-	result.WriteString(titleWithSpaces) // Title in default color
+func (r *bracketRenderer) Inset(ctx RenderContext) int {
+	// Left border plus space, with the padding after it and the same again on the right
+	return 2 + 2*ctx.Padding
+}
 
-	result.WriteString("\n")
-	return result.String()
+func (r *bracketRenderer) Edges(color ansi.Color) (string, string) {
+	return color.Sprint(boxVertical + "  "), ""
 }
 
-func (r *bracketRenderer) closeFrame(elapsed time.Duration, color ansi.Color) string {
-	depth := stack.depth()
+// formatTemplate processes ANSI template syntax (e.g., {{bold+cyan:work}}) so that width calculations
+// are based on the final rendered content
+func formatTemplate(s string) string {
+	if strings.Contains(s, "{{") && strings.Contains(s, "}}") {
+		return ansi.Format(s)
+	}
+
+	return s
+}
 
-	// Create bottom border with timing
-	var timingText string
-	if elapsed > time.Millisecond {
-		timingText = " (" + elapsed.Round(time.Millisecond).String() + ") "
+// fitHeading formats a title or divider heading with a space either side, truncating it with an
+// ellipsis so that it fits within width. An empty heading, or one with no room at all, renders as "".
+func fitHeading(heading string, width int) string {
+	if heading == "" {
+		return ""
 	}
 
-	// Build the line
-	var result strings.Builder
+	heading = formatTemplate(heading)
+	if strlen(heading)+2 <= width {
+		return " " + heading + " "
+	}
 
-	// Add parent frame prefixes with their original colors
-	renderParentPrefixes(&result, depth)
+	if width < 6 {
+		return ""
+	}
 
-	// Add current frame's bottom border without horizontal fill or right border
-	bottomBorder := color.Sprint(boxBottomLeft + strings.Repeat(boxHorizontal, 2))
-	result.WriteString(bottomBorder)
-	result.WriteString(timingText) // Timing in default color
+	return " " + term.TruncateString(heading, width-5) + "... "
+}
+
+// fitWidth truncates s with an ellipsis when it is wider than width, and pads it with spaces when it is
+// narrower, so that it exactly fills width
+func fitWidth(s string, width int) string {
+	if strlen(s) > width {
+		s = term.TruncateString(s, max(width-3, 0)) + "..."
+	}
 
-	result.WriteString("\n")
-	return result.String()
+	return s + strings.Repeat(" ", max(width-strlen(s), 0))
 }
 
-func (r *bracketRenderer) createDivider(text string, color ansi.Color) string {
-	// Create divider text with spaces but without color applied yet
-	var textWithSpaces string
-	if text != "" {
-		textWithSpaces = " " + text + " "
+// alignWidth fits s to width like fitWidth, placing it according to align
+func alignWidth(s string, width int, align Alignment) string {
+	s = strings.TrimRight(fitWidth(s, width), " ")
+	gap := max(width-strlen(s), 0)
+
+	var before int
+	switch align {
+	case AlignCenter:
+		before = gap / 2
+	case AlignRight:
+		before = gap
+	case AlignLeft:
 	}
 
-	// Build the line
-	var result strings.Builder
+	return strings.Repeat(" ", before) + s + strings.Repeat(" ", gap-before)
+}
 
-	// Add parent frame prefixes with their original colors
-	renderParentPrefixes(&result, stack.depth())
+// headingFill splits the border characters either side of a title or divider heading according to
+// align. At least two are kept before the heading, as with left alignment.
+func headingFill(total int, align Alignment) (before, after int) {
+	before = 2
+	switch align {
+	case AlignCenter:
+		before = total / 2
+	case AlignRight:
+		before = total - 2
+	case AlignLeft:
+	}
 
-	// Add current frame's divider without horizontal fill or right border
-	leftBorder := color.Sprint(boxTee + strings.Repeat(boxHorizontal, 2))
+	before = max(min(before, total), min(2, total))
+	return before, max(total-before, 0)
+}
 
-	result.WriteString(leftBorder)
-	result.WriteString(textWithSpaces) // Text in default color
+// closingTiming returns the elapsed time shown in a frame's closing border, or an empty string for
+// frames that closed within a millisecond
+func closingTiming(elapsed time.Duration) string {
+	if elapsed > time.Millisecond {
+		return " (" + elapsed.Round(time.Millisecond).String() + ") "
+	}
 
-	result.WriteString("\n")
-	return result.String()
+	return ""
 }
 
 func strlen(s string) int {
@@ -0,0 +1,327 @@
+package frame
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/pseudomuto/gooey/ci"
+	"github.com/pseudomuto/gooey/internal/term"
+	"github.com/pseudomuto/gooey/terminal"
+)
+
+// columnGap is the number of blank columns between neighbouring frames in a row
+const columnGap = 1
+
+type (
+	// Row renders frames side by side, each taking a share of the available width. Create one with
+	// OpenRow.
+	Row struct {
+		titles        []string
+		weights       []float64
+		minWidths     []int
+		columnOptions []FrameOption
+		output        io.Writer
+		stack         *Stack
+		env           terminal.Environment
+		parent        *Frame // the frame the row is rendered inside, if any
+		widths        []int
+		columns       []*Frame
+		buffers       []*bytes.Buffer
+		untrack       func()
+		closeMutex    sync.Mutex // serialises closing, since Interrupt runs on the signal handler's goroutine
+		closed        bool
+	}
+
+	RowOption func(*Row)
+)
+
+// OpenRow opens a frame for each title and lays them out side by side, e.g. to compare staging and
+// production. The available width (the terminal's, or the width inside the innermost open frame)
+// is shared between the columns with term.SectionLayout, equally unless WithWeights is given.
+//
+// Each column is an ordinary frame rendered at its own width, so long lines are truncated to fit their
+// column rather than the terminal. Columns are held back while the row is open, and written out line by
+// line when the row closes. Closing the row pads the columns that are still open so their closing
+// borders line up, then closes them.
+//
+// Example:
+//
+//	row := frame.OpenRow([]string{"staging", "production"}, frame.WithWeights(1, 1))
+//	staging, production := row.Column(0), row.Column(1)
+//	staging.Println("v1.4.2")
+//	production.Println("v1.4.1")
+//	production.Println("3 pods pending")
+//	row.Close()
+//
+//	// Shows: ┌── staging ──────────┐ ┌── production ───────┐
+//	//        │ v1.4.2              │ │ v1.4.1              │
+//	//        │                     │ │ 3 pods pending      │
+//	//        └─────────────────────┘ └─────────────────────┘
+func OpenRow(titles []string, options ...RowOption) *Row {
+	r := &Row{
+		titles: titles,
+		output: defaultFrameOutput,
+		env:    terminal.Default(),
+	}
+
+	for _, option := range options {
+		option(r)
+	}
+
+	r.env = r.env.Detect()
+	if r.stack == nil {
+		r.stack = lookupStack(r.output)
+	}
+
+	// Interrupted columns close first, as they're tracked after the row, so the row writes them out
+	r.untrack = terminal.Track(r)
+	r.parent = r.stack.Current()
+	r.widths = r.sectionWidths()
+	for i, title := range titles {
+		buffer := new(bytes.Buffer)
+		env := terminal.Environment{
+			Width: r.widths[i], Height: r.env.Height, TTY: terminal.TTYOff, Color: r.env.Color, CI: ci.None,
+		}
+		columnOptions := append(append([]FrameOption(nil), r.columnOptions...),
+			WithOutput(buffer), WithStack(NewStack()), WithTerminal(env))
+
+		r.buffers = append(r.buffers, buffer)
+		r.columns = append(r.columns, Open(title, columnOptions...))
+	}
+
+	return r
+}
+
+// Columns returns the row's frames, in the order of the titles given to OpenRow.
+func (r *Row) Columns() []*Frame {
+	return r.columns
+}
+
+// Column returns the frame for the i-th title given to OpenRow.
+func (r *Row) Column(i int) *Frame {
+	return r.columns[i]
+}
+
+// Close pads the columns that are still open to the height of the tallest one and closes them, then
+// writes the row out. Columns closed earlier (e.g. with CloseWithError) are padded below their closing
+// border instead. Calling Close more than once has no effect.
+//
+// Example:
+//
+//	row := frame.OpenRow([]string{"api", "worker"})
+//	defer row.Close()
+func (r *Row) Close() {
+	r.closeMutex.Lock()
+	defer r.closeMutex.Unlock()
+
+	if r.closed {
+		return
+	}
+
+	r.closed = true
+	r.untrack()
+
+	// Open columns haven't drawn their closing border yet, so the tallest content is one row shorter
+	// than a closed column of the same height
+	height := 0
+	for _, column := range r.columns {
+		rows := column.rowCount()
+		if !column.stack.contains(column) {
+			rows--
+		}
+
+		height = max(height, rows)
+	}
+
+	for _, column := range r.columns {
+		if column.stack.contains(column) {
+			for range height - column.rowCount() {
+				column.Println("")
+			}
+
+			column.Close()
+		}
+	}
+
+	r.flush()
+}
+
+// Interrupt writes out the row when the process is interrupted or panics. The columns have been
+// interrupted by then, so each shows its failure marker.
+func (r *Row) Interrupt() {
+	r.Close()
+}
+
+// flush writes the columns out side by side, filling columns shorter than the tallest with blanks
+func (r *Row) flush() {
+	columnLines := make([][]string, len(r.buffers))
+	height := 0
+	for i, buffer := range r.buffers {
+		if buffer.Len() > 0 {
+			columnLines[i] = strings.Split(strings.TrimSuffix(buffer.String(), "\n"), "\n")
+		}
+
+		height = max(height, len(columnLines[i]))
+	}
+
+	var out strings.Builder
+	for row := range height {
+		cells := make([]string, len(columnLines))
+		for i, lines := range columnLines {
+			var cell string
+			if row < len(lines) {
+				cell = lines[row]
+			}
+
+			cells[i] = fitWidth(cell, r.widths[i])
+		}
+
+		line := strings.TrimRight(strings.Join(cells, strings.Repeat(" ", columnGap)), " ")
+		if r.parent != nil {
+			// Lined up with the edges of the parent, like a frame nested inside it
+			line = r.parent.nestedContext().line(line)
+		}
+
+		out.WriteString(line + "\n")
+	}
+
+	if out.Len() == 0 {
+		return
+	}
+
+	if r.parent != nil {
+		r.parent.settle()
+		r.parent.emit(out.String())
+		return
+	}
+
+	_ = writeOutput(r.output, out.String())
+}
+
+// sectionWidths shares the available width, less the gaps between columns, between the columns
+func (r *Row) sectionWidths() []int {
+	if len(r.titles) == 0 {
+		return nil
+	}
+
+	width := r.env.Columns()
+	if r.parent != nil {
+		width = r.parent.nestedContext().Width
+	}
+
+	weights := r.weights
+	if len(weights) != len(r.titles) {
+		weights = make([]float64, len(r.titles))
+		for i := range weights {
+			weights[i] = 1
+		}
+	}
+
+	available := max(width-columnGap*(len(r.titles)-1), len(r.titles)*minFrameWidth)
+	widths := term.NewSectionLayout(available, weights...).WithMinWidths(r.minWidths...).SectionWidths()
+
+	// The layout rounds each share down, so the last column takes up what's left over
+	used := 0
+	for _, w := range widths {
+		used += w
+	}
+
+	widths[len(widths)-1] += max(available-used, 0)
+	return widths
+}
+
+// nestedContext returns the render context of a frame nested inside f, which is surrounded by the edges
+// of f and its parents
+func (f *Frame) nestedContext() RenderContext {
+	ctx := f.renderContext()
+	ctx.Parents = append(ctx.Parents, ParentFrame{Color: ctx.Color, Renderer: f.renderer, Padding: f.padding})
+	ctx.Depth++
+
+	left, right := ctx.edges()
+	ctx.Width = max(f.env.Columns()-strlen(left)-strlen(right), minFrameWidth)
+	return ctx
+}
+
+// rowCount returns the rows written by f since the start of its opening border
+func (f *Frame) rowCount() int {
+	f.stack.writeMutex.Lock()
+	defer f.stack.writeMutex.Unlock()
+	return f.rows
+}
+
+// WithWeights sets the relative widths of a row's columns, one weight per title. Rows without weights,
+// or with the wrong number of them, share the width equally.
+//
+// Example:
+//
+//	// The logs column is twice as wide as the status column
+//	row := frame.OpenRow([]string{"status", "logs"}, frame.WithWeights(1, 2))
+func WithWeights(weights ...float64) RowOption {
+	return func(r *Row) {
+		r.weights = weights
+	}
+}
+
+// WithMinWidths sets the minimum width of each of a row's columns, taking precedence over their weights.
+//
+// Example:
+//
+//	row := frame.OpenRow([]string{"id", "description"}, frame.WithWeights(1, 4), frame.WithMinWidths(20))
+func WithMinWidths(widths ...int) RowOption {
+	return func(r *Row) {
+		r.minWidths = widths
+	}
+}
+
+// WithColumnOptions applies frame options (e.g. WithColor or WithStyle) to every column of a row. The
+// output, stack and terminal of the columns are managed by the row, so those options are ignored.
+//
+// Example:
+//
+//	row := frame.OpenRow([]string{"before", "after"}, frame.WithColumnOptions(frame.WithColor(ansi.Green)))
+func WithColumnOptions(options ...FrameOption) RowOption {
+	return func(r *Row) {
+		r.columnOptions = append(r.columnOptions, options...)
+	}
+}
+
+// WithRowOutput sets the writer a row is written to. It defaults to os.Stdout. The row is rendered
+// inside the innermost frame open on the writer's stack, if there is one.
+//
+// Example:
+//
+//	var buf bytes.Buffer
+//	row := frame.OpenRow([]string{"a", "b"}, frame.WithRowOutput(&buf))
+func WithRowOutput(output io.Writer) RowOption {
+	return func(r *Row) {
+		r.output = output
+	}
+}
+
+// WithRowStack sets the stack whose innermost open frame the row is rendered inside, like WithStack
+// does for frames.
+//
+// Example:
+//
+//	stack := frame.NewStack()
+//	outer := stack.Open("Deploy", frame.WithOutput(&buf))
+//	row := frame.OpenRow([]string{"staging", "production"}, frame.WithRowStack(stack))
+func WithRowStack(stack *Stack) RowOption {
+	return func(r *Row) {
+		r.stack = stack
+	}
+}
+
+// WithRowTerminal sets the terminal environment used to work out the width of a row that isn't nested
+// inside a frame, and the colour profile of its columns.
+//
+// Example:
+//
+//	row := frame.OpenRow(titles, frame.WithRowTerminal(terminal.Environment{Width: 120}))
+func WithRowTerminal(env terminal.Environment) RowOption {
+	return func(r *Row) {
+		r.env = env
+	}
+}
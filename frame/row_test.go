@@ -0,0 +1,138 @@
+package frame_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/pseudomuto/gooey/ci"
+	. "github.com/pseudomuto/gooey/frame"
+	"github.com/pseudomuto/gooey/terminal"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRow(t *testing.T) {
+	tests := []struct {
+		name     string
+		titles   []string
+		options  []RowOption
+		write    func(r *Row)
+		expected []string
+	}{
+		{
+			name:   "balances column heights",
+			titles: []string{"staging", "production"},
+			write: func(r *Row) {
+				r.Column(0).Println("v1.4.2")
+				r.Column(1).Println("v1.4.1")
+				r.Column(1).Println("3 pods pending")
+			},
+			expected: []string{
+				"┌── staging ─────┐ ┌── production ──┐",
+				"│ v1.4.2         │ │ v1.4.1         │",
+				"│                │ │ 3 pods pending │",
+				"└────────────────┘ └────────────────┘",
+			},
+		},
+		{
+			name:    "weights and per-column truncation",
+			titles:  []string{"status", "logs"},
+			options: []RowOption{WithWeights(1, 2)},
+			write: func(r *Row) {
+				r.Column(0).Println("healthy and serving traffic")
+				r.Column(1).Println("GET /healthz 200")
+			},
+			expected: []string{
+				"┌── status ┐ ┌── logs ──────────────┐",
+				"│ health...│ │ GET /healthz 200     │",
+				"└──────────┘ └──────────────────────┘",
+			},
+		},
+		{
+			name:   "column closed early",
+			titles: []string{"api", "worker"},
+			write: func(r *Row) {
+				r.Column(0).Println("ok")
+				r.Column(0).Close()
+				r.Column(1).Println("one")
+				r.Column(1).Println("two")
+				r.Column(1).Println("three")
+			},
+			expected: []string{
+				"┌── api ─────────┐ ┌── worker ──────┐",
+				"│ ok             │ │ one            │",
+				"└────────────────┘ │ two            │",
+				"                   │ three          │",
+				"                   └────────────────┘",
+			},
+		},
+		{
+			name:   "three columns",
+			titles: []string{"a", "b", "c"},
+			write: func(r *Row) {
+				r.Column(1).Println("middle")
+			},
+			expected: []string{
+				"┌── a ────┐ ┌── b ────┐ ┌── c ──────┐",
+				"│         │ │ middle  │ │           │",
+				"└─────────┘ └─────────┘ └───────────┘",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			env := terminal.Environment{Width: 37, Height: 24, TTY: terminal.TTYOff, Color: terminal.ColorNone, CI: ci.None}
+			row := OpenRow(tt.titles, testRowOptions(&buf, env, tt.options...)...)
+			require.Len(t, row.Columns(), len(tt.titles))
+
+			tt.write(row)
+			require.Empty(t, buf.String(), "columns are held back until the row closes")
+
+			row.Close()
+			row.Close()
+			require.Equal(t, strings.Join(tt.expected, "\n")+"\n", buf.String())
+		})
+	}
+}
+
+func TestRowInsideFrame(t *testing.T) {
+	var buf bytes.Buffer
+	stack := NewStack()
+	env := terminal.Environment{Width: 40, Height: 24, TTY: terminal.TTYOff, Color: terminal.ColorNone, CI: ci.None}
+
+	outer := Open("Deploy", testOptions(&buf, env, WithStack(stack))...)
+	row := OpenRow([]string{"staging", "production"}, testRowOptions(&buf, env, WithRowStack(stack))...)
+	row.Column(0).Println("v2")
+	row.Close()
+	outer.Close()
+
+	expected := []string{
+		"┌── Deploy ────────────────────────────┐",
+		"│  ┌── staging ────┐ ┌── production ─┐ │",
+		"│  │ v2            │ │               │ │",
+		"│  └───────────────┘ └───────────────┘ │",
+		"└──────────────────────────────────────┘",
+		"",
+	}
+	require.Equal(t, strings.Join(expected, "\n"), buf.String())
+}
+
+func TestRowInterruptWhileClosing(t *testing.T) {
+	var buf bytes.Buffer
+	env := terminal.Environment{Width: 40, TTY: terminal.TTYOff, Color: terminal.ColorNone, CI: ci.None}
+	row := OpenRow([]string{"staging", "production"}, testRowOptions(&buf, env)...)
+
+	// Interrupts arrive on the signal handler's goroutine while the caller closes the row
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		row.Close()
+	}()
+
+	row.Interrupt()
+	<-done
+
+	require.Equal(t, 1, strings.Count(buf.String(), "┌── staging"))
+}
@@ -0,0 +1,104 @@
+package frame
+
+import (
+	"time"
+
+	"github.com/pseudomuto/gooey/clock"
+)
+
+type (
+	// TitleSpinner is a spinner that can be animated in a frame's title. *spinner.Spinner implements it,
+	// drawing the glyphs of its SpinnerRenderer.
+	TitleSpinner interface {
+		// Glyph returns the spinner's glyph for an animation frame, without any message.
+		Glyph(frame int) string
+		// Interval returns the time between animation frames.
+		Interval() time.Duration
+	}
+
+	// titleSpinner animates a TitleSpinner in a frame's opening border
+	titleSpinner struct {
+		spinner TitleSpinner
+		frame   int    // the current animation frame, guarded by the frame's titleMutex
+		final   string // the glyph shown once the frame has closed, guarded by the frame's titleMutex
+		static  bool   // set when the border can't be redrawn, so the final glyph goes in the closing border
+		stop    chan struct{}
+		done    chan struct{}
+	}
+)
+
+// WithSpinner animates a spinner before the frame's title while the frame is open, and replaces it with
+// a check mark, cross or warning sign matching the status the frame is closed with. The opening border
+// is redrawn in place as content is written below it, so the spinner is only animated on a TTY.
+// Elsewhere, e.g. in CI logs, the title is left as it is and the final glyph is shown in the closing
+// border instead.
+//
+// Example:
+//
+//	f := frame.Open("Build", frame.WithSpinner(spinner.New("", spinner.WithRenderer(spinner.Dots))))
+//	f.Println("compiling...")
+//	f.Close()
+//
+//	// While open shows: ┌── ⠋ Build ───────────┐
+//	// Once closed:      ┌── ✓ Build ───────────┐
+//	// In logs:          └───────────── ✓ (1.2s) ┘
+func WithSpinner(spinner TitleSpinner) FrameOption {
+	return func(f *Frame) {
+		f.spinner = &titleSpinner{spinner: spinner}
+	}
+}
+
+// startSpinner starts animating the title spinner
+func (f *Frame) startSpinner() {
+	s := f.spinner
+	s.stop = make(chan struct{})
+	s.done = make(chan struct{})
+
+	// Create the ticker before starting the goroutine so that no tick can be missed
+	go f.animateSpinner(f.clock.NewTicker(s.spinner.Interval()))
+}
+
+func (f *Frame) animateSpinner(ticker clock.Ticker) {
+	defer close(f.spinner.done)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-f.spinner.stop:
+			return
+		case <-ticker.C():
+			f.titleMutex.Lock()
+			f.spinner.frame++
+			f.titleMutex.Unlock()
+
+			f.redrawBorder()
+		}
+	}
+}
+
+// stopSpinner stops animating the title spinner and replaces it with glyph
+func (f *Frame) stopSpinner(glyph string) {
+	s := f.spinner
+	if s == nil || s.stop == nil {
+		return
+	}
+
+	close(s.stop)
+	<-s.done
+	s.stop = nil
+
+	f.titleMutex.Lock()
+	s.final = glyph
+	f.titleMutex.Unlock()
+
+	f.redrawBorder()
+}
+
+// glyph returns the glyph to show before the title. The caller must hold the frame's titleMutex.
+func (s *titleSpinner) glyph() string {
+	if s.final != "" {
+		return s.final
+	}
+
+	return s.spinner.Glyph(s.frame)
+}
@@ -0,0 +1,71 @@
+package frame_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pseudomuto/gooey/ci"
+	. "github.com/pseudomuto/gooey/frame"
+	"github.com/pseudomuto/gooey/terminal"
+	"github.com/pseudomuto/gooey/termtest"
+	"github.com/stretchr/testify/require"
+)
+
+type letterSpinner struct{}
+
+func (letterSpinner) Glyph(frame int) string {
+	return string(rune('a' + frame%26))
+}
+
+func (letterSpinner) Interval() time.Duration {
+	return 100 * time.Millisecond
+}
+
+func TestFrameWithSpinner(t *testing.T) {
+	fake := newTestClock()
+	vt := termtest.New(30)
+	env := terminal.Environment{Width: 30, Height: 24, TTY: terminal.TTYOn, Color: terminal.ColorNone, CI: ci.None}
+	f := Open("Build", testOptions(vt, env, WithClock(fake), WithSpinner(letterSpinner{}))...)
+	require.Equal(t, "┌── a Build ─────────────────┐", vt.Screen().Line(0))
+
+	f.Println("compiling")
+	f.Print("linking")
+	fake.Advance(200 * time.Millisecond)
+	require.Eventually(t, func() bool {
+		return vt.Screen().Line(0) == "┌── c Build ─────────────────┐"
+	}, time.Second, time.Millisecond)
+
+	// Content carries on below the header
+	f.Println("...done")
+	f.CloseWithStatus(StatusFailure)
+
+	require.Equal(t, strings.Join([]string{
+		"┌── ✗ Build ─────────────────┐",
+		"│ compiling                  │",
+		"│ linking                    │",
+		"│ ...done                    │",
+		"└───────────────── ✗ (200ms) ┘",
+		"",
+	}, "\n"), vt.Screen().String())
+}
+
+func TestFrameWithSpinnerWithoutTTY(t *testing.T) {
+	fake := newTestClock()
+	vt := termtest.New(30)
+	env := terminal.Environment{Width: 30, Height: 24, TTY: terminal.TTYOff, Color: terminal.ColorNone, CI: ci.None}
+	f := Open("Build", testOptions(vt, env, WithClock(fake), WithSpinner(letterSpinner{}))...)
+	fake.Advance(time.Second)
+	f.Close()
+
+	failed := Open("Test", testOptions(vt, env, WithClock(fake), WithSpinner(letterSpinner{}))...)
+	failed.CloseWithStatus(StatusFailure)
+
+	require.Equal(t, strings.Join([]string{
+		"┌── Build ───────────────────┐",
+		"└──────────────────── ✓ (1s) ┘",
+		"┌── Test ────────────────────┐",
+		"└───────────────────────── ✗ ┘",
+		"",
+	}, "\n"), vt.Screen().String())
+}
@@ -1,80 +1,228 @@
 package frame
 
 import (
+	"io"
+	"reflect"
+	"slices"
 	"sync"
 
 	"github.com/pseudomuto/gooey/ansi"
 )
 
-type frameStack struct {
-	frames []*Frame
-	mutex  sync.RWMutex
+var (
+	defaultStack = NewStack()
+
+	writerStacks      = make(map[io.Writer]*Stack)
+	writerStacksMutex sync.Mutex
+)
+
+// Stack tracks the open frames of one frame tree. Frames opened on the same stack nest inside each other,
+// while frames on different stacks are independent, so separate trees can be rendered to different
+// writers or from different goroutines without corrupting each other's depth and parent colours.
+//
+// Frames opened without WithStack use the stack bound to their output writer with StackFor, or
+// DefaultStack when they write to the default output (os.Stdout). Frames written anywhere else share a
+// stack with the other frames open on the same writer, which is released once they've all closed. A
+// Stack is safe for concurrent use.
+type Stack struct {
+	frames     []*Frame
+	overrides  []*ansi.Color // the colours of the running WithFrameColorOverride calls, latest last
+	mutex      sync.RWMutex
+	writeMutex sync.Mutex // serialises output from the stack's frames, which may come from several goroutines
+
+	// output is the writer an automatically resolved stack belongs to, and refs counts the frames using
+	// it that haven't closed. Both are guarded by writerStacksMutex.
+	output io.Writer
+	refs   int
 }
 
-func (fs *frameStack) push(frame *Frame) {
-	fs.mutex.Lock()
-	defer fs.mutex.Unlock()
-	fs.frames = append(fs.frames, frame)
+// NewStack creates an empty frame stack.
+//
+// Example:
+//
+//	// Each worker renders its own frame tree into its own buffer
+//	for _, job := range jobs {
+//		go func() {
+//			var buf bytes.Buffer
+//			stack := frame.NewStack()
+//			f := stack.Open(job.Name, frame.WithOutput(&buf))
+//			defer f.Close()
+//			job.Run(f)
+//		}()
+//	}
+func NewStack() *Stack {
+	return new(Stack)
 }
 
-func (fs *frameStack) pop() *Frame {
-	fs.mutex.Lock()
-	defer fs.mutex.Unlock()
-	if len(fs.frames) == 0 {
-		return nil
+// DefaultStack returns the process-wide stack used by frames that write to the default output and weren't
+// given a stack explicitly.
+func DefaultStack() *Stack {
+	return defaultStack
+}
+
+// StackFor returns the stack that frames opened with WithOutput(w) nest on, creating one if there isn't
+// one yet, and binds it to w. Frames written to w share a stack without it, but that stack is released
+// once they've all closed, while a bound stack lasts for the life of the process. This makes it possible
+// to use the stack before or between frames, e.g. to override its colour. Writers that can't be compared
+// (e.g. slices or maps) are never bound, and a new unbound stack is returned for them.
+//
+// Example:
+//
+//	logFile, _ := os.Create("build.log")
+//	stack := frame.StackFor(logFile)
+//
+//	console := frame.Open("Build")                             // nests on the default stack
+//	log := frame.Open("Build", frame.WithOutput(logFile))      // nests on stack
+//	step := frame.Open("Compile", frame.WithOutput(logFile))   // nested inside log, not console
+func StackFor(w io.Writer) *Stack {
+	if w == nil || !reflect.TypeOf(w).Comparable() {
+		return NewStack()
+	}
+
+	writerStacksMutex.Lock()
+	defer writerStacksMutex.Unlock()
+
+	if s, ok := writerStacks[w]; ok {
+		// Frames may already be open on a stack resolved for w, which is now kept for good
+		s.output = nil
+		return s
+	}
+
+	s := NewStack()
+	writerStacks[w] = s
+	return s
+}
+
+// resolveStack returns the stack for a frame writing to w that wasn't given one. Stacks resolved for
+// writers other than the default output are shared by the frames open on them. Each frame releases its
+// reference when it closes, and the stack is unbound from w once the last one has, unless it's been bound
+// with StackFor in the meantime.
+func resolveStack(w io.Writer) *Stack {
+	if w == nil || w == defaultFrameOutput || !reflect.TypeOf(w).Comparable() {
+		return defaultStack
+	}
+
+	writerStacksMutex.Lock()
+	defer writerStacksMutex.Unlock()
+
+	s, ok := writerStacks[w]
+	if !ok {
+		s = NewStack()
+		s.output = w
+		writerStacks[w] = s
+	}
+
+	if s.output != nil {
+		s.refs++
+	}
+
+	return s
+}
+
+// lookupStack returns the stack frames writing to w are opened on, without resolving a new one
+func lookupStack(w io.Writer) *Stack {
+	if w == nil || w == defaultFrameOutput || !reflect.TypeOf(w).Comparable() {
+		return defaultStack
+	}
+
+	writerStacksMutex.Lock()
+	defer writerStacksMutex.Unlock()
+
+	if s, ok := writerStacks[w]; ok {
+		return s
 	}
 
-	frame := fs.frames[len(fs.frames)-1]
-	fs.frames = fs.frames[:len(fs.frames)-1]
-	return frame
+	return defaultStack
+}
+
+// release drops a reference to a stack returned by resolveStack, unbinding it from its writer once no
+// frames are using it
+func (s *Stack) release() {
+	writerStacksMutex.Lock()
+	defer writerStacksMutex.Unlock()
+
+	if s.output == nil {
+		return
+	}
+
+	s.refs--
+	if s.refs == 0 {
+		delete(writerStacks, s.output)
+		s.output = nil
+	}
 }
 
-func (fs *frameStack) current() *Frame {
-	fs.mutex.RLock()
-	defer fs.mutex.RUnlock()
-	if len(fs.frames) == 0 {
+// Open opens a frame on this stack. It is equivalent to calling frame.Open with WithStack(s).
+func (s *Stack) Open(title string, options ...FrameOption) *Frame {
+	return Open(title, append([]FrameOption{WithStack(s)}, options...)...)
+}
+
+// Current returns the innermost open frame on the stack, or nil when no frames are open.
+func (s *Stack) Current() *Frame {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	if len(s.frames) == 0 {
 		return nil
 	}
 
-	return fs.frames[len(fs.frames)-1]
+	return s.frames[len(s.frames)-1]
 }
 
-func (fs *frameStack) depth() int {
-	fs.mutex.RLock()
-	defer fs.mutex.RUnlock()
+// Depth returns the number of open frames on the stack.
+func (s *Stack) Depth() int {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return len(s.frames)
+}
 
-	return len(fs.frames)
+func (s *Stack) push(frame *Frame) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.frames = append(s.frames, frame)
 }
 
-// frameDepth returns the 1-based depth position of a specific frame in the stack
-// Returns 0 if the frame is not found
-func (fs *frameStack) frameDepth(frame *Frame) int {
-	fs.mutex.RLock()
-	defer fs.mutex.RUnlock()
-	for i, f := range fs.frames {
-		if f == frame {
-			return i + 1 // 1-based depth
-		}
+// popIf removes frame from the top of the stack, reporting false (and leaving the stack untouched) when
+// frame isn't the innermost open frame
+func (s *Stack) popIf(frame *Frame) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if len(s.frames) == 0 || s.frames[len(s.frames)-1] != frame {
+		return false
 	}
 
-	return 0
+	s.frames = s.frames[:len(s.frames)-1]
+	return true
+}
+
+// contains reports whether frame is open on the stack
+func (s *Stack) contains(frame *Frame) bool {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return slices.Contains(s.frames, frame)
 }
 
-// frameColors returns the colors of frames in the stack up to the specified depth
-func (fs *frameStack) frameColors(maxDepth int) []ansi.Color {
-	fs.mutex.RLock()
-	defer fs.mutex.RUnlock()
-
-	colors := make([]ansi.Color, 0, min(maxDepth, len(fs.frames)))
-	for i := 0; i < min(maxDepth, len(fs.frames)); i++ {
-		frameColorMutex.RLock()
-		color := fs.frames[i].color
-		if frameColorOverride != nil {
-			color = *frameColorOverride
+// parents returns the frames enclosing the given frame, outermost first. Frames that aren't on the
+// stack have no parents.
+func (s *Stack) parents(frame *Frame) []ParentFrame {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	for i, f := range s.frames {
+		if f != frame {
+			continue
 		}
-		frameColorMutex.RUnlock()
-		colors = append(colors, color)
+
+		parents := make([]ParentFrame, 0, i)
+		for _, parent := range s.frames[:i] {
+			color := parent.color
+			if override := s.override(); override != nil {
+				color = *override
+			}
+			parents = append(parents, ParentFrame{Color: color, Renderer: parent.renderer, Padding: parent.padding})
+		}
+
+		return parents
 	}
 
-	return colors
+	return nil
 }
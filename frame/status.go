@@ -0,0 +1,101 @@
+package frame
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/pseudomuto/gooey/ansi"
+)
+
+// Run opens a frame, calls fn with it and closes the frame with the outcome: StatusSuccess when fn
+// returns nil, and StatusFailure with the error chain printed inside the frame otherwise. If fn panics,
+// the panic is recovered and reported the same way. The error (or recovered panic) is returned.
+//
+// Example:
+//
+//	err := frame.Run("Deploy", func(f *frame.Frame) error {
+//		f.Println("Uploading artifacts...")
+//		return upload()
+//	}, frame.WithColor(ansi.Blue))
+func Run(title string, fn func(f *Frame) error, options ...FrameOption) (err error) {
+	f := Open(title, options...)
+	defer func() {
+		if r := recover(); r != nil {
+			err = panicError(r)
+		}
+
+		err = f.CloseWithError(err)
+	}()
+
+	return fn(f)
+}
+
+// String returns the status name.
+func (s Status) String() string {
+	switch s {
+	case StatusSuccess:
+		return "success"
+	case StatusFailure:
+		return "failure"
+	case StatusWarning:
+		return "warning"
+	}
+
+	return fmt.Sprintf("Status(%d)", int(s))
+}
+
+func (s Status) icon() ansi.Icon {
+	switch s {
+	case StatusFailure:
+		return ansi.CrossMark
+	case StatusWarning:
+		return ansi.Warning
+	case StatusSuccess:
+	}
+
+	return ansi.CheckMark
+}
+
+func (s Status) color() ansi.Color {
+	switch s {
+	case StatusFailure:
+		return ansi.Red
+	case StatusWarning:
+		return ansi.Yellow
+	case StatusSuccess:
+	}
+
+	return ansi.Green
+}
+
+// panicError converts a recovered panic value into an error
+func panicError(r any) error {
+	if err, ok := r.(error); ok {
+		return errors.Wrap(err, "panic")
+	}
+
+	return errors.Errorf("panic: %v", r)
+}
+
+// errorChain returns the message of err followed by the message of each error it wraps. Each message
+// only contains the text its error adds, so "deploy: connect: refused" becomes "deploy", "connect" and
+// "refused". Wrappers that add no text of their own, such as stack traces, are skipped.
+func errorChain(err error) []string {
+	var messages []string
+	for err != nil {
+		message := err.Error()
+		next := errors.Unwrap(err)
+		if next != nil {
+			message = strings.TrimSuffix(strings.TrimSuffix(message, next.Error()), ": ")
+		}
+
+		if message != "" {
+			messages = append(messages, message)
+		}
+
+		err = next
+	}
+
+	return messages
+}
@@ -0,0 +1,113 @@
+package frame_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/pseudomuto/gooey/ansi"
+	"github.com/pseudomuto/gooey/ci"
+	. "github.com/pseudomuto/gooey/frame"
+	"github.com/pseudomuto/gooey/terminal"
+	"github.com/pseudomuto/gooey/termtest"
+	"github.com/stretchr/testify/require"
+)
+
+// statusTestEnv is the terminal the frames in these tests are opened in
+var statusTestEnv = terminal.Environment{Width: 36, Color: terminal.ColorANSI, CI: ci.None}
+
+func TestFrameCloseWithStatus(t *testing.T) {
+	tests := []struct {
+		status Status
+		line   string
+		color  ansi.Color
+	}{
+		{StatusSuccess, "└─────────────────────────────── ✓ ┘\n", ansi.Green},
+		{StatusFailure, "└─────────────────────────────── ✗ ┘\n", ansi.Red},
+		{StatusWarning, "└─────────────────────────────── ⚠ ┘\n", ansi.Yellow},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.status.String(), func(t *testing.T) {
+			vt := termtest.New(36)
+			f := Open("Task", testOptions(vt, statusTestEnv)...)
+			f.CloseWithStatus(tt.status)
+
+			require.Equal(t, tt.line, vt.Screen().Line(1)+"\n")
+			require.Equal(t, tt.color, vt.Screen().Cell(1, 0).Style.Foreground)
+			require.Equal(t, tt.color, vt.Screen().Cell(1, 33).Style.Foreground)
+
+			// The opening border keeps the frame's own colour
+			require.Equal(t, ansi.Cyan, vt.Screen().Cell(0, 0).Style.Foreground)
+		})
+	}
+}
+
+func TestFrameCloseWithError(t *testing.T) {
+	vt := termtest.New(36)
+	f := Open("Deploy", testOptions(vt, statusTestEnv)...)
+
+	cause := errors.New("connection refused")
+	err := errors.Wrap(errors.Wrap(cause, "upload"), "deploy failed")
+	require.Same(t, err, f.CloseWithError(err))
+
+	require.Equal(t, strings.Join([]string{
+		"┌── Deploy ────────────────────────┐",
+		"│ ✗ deploy failed                  │",
+		"│   caused by: upload              │",
+		"│   caused by: connection refused  │",
+		"└─────────────────────────────── ✗ ┘",
+		"",
+	}, "\n"), vt.Screen().String())
+}
+
+func TestFrameCloseWithNilError(t *testing.T) {
+	vt := termtest.New(36)
+	f := Open("Deploy", testOptions(vt, statusTestEnv)...)
+
+	require.NoError(t, f.CloseWithError(nil))
+	require.Contains(t, vt.Screen().String(), "✓ ┘")
+}
+
+func TestRun(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		vt := termtest.New(36)
+		err := Run("Build", func(f *Frame) error {
+			f.Println("compiled")
+			return nil
+		}, testOptions(vt, statusTestEnv)...)
+
+		require.NoError(t, err)
+		require.Equal(t, strings.Join([]string{
+			"┌── Build ─────────────────────────┐",
+			"│ compiled                         │",
+			"└─────────────────────────────── ✓ ┘",
+			"",
+		}, "\n"), vt.Screen().String())
+	})
+
+	t.Run("error", func(t *testing.T) {
+		vt := termtest.New(36)
+		err := Run("Build", func(f *Frame) error {
+			return errors.New("syntax error")
+		}, testOptions(vt, statusTestEnv)...)
+
+		require.EqualError(t, err, "syntax error")
+		require.Contains(t, vt.Screen().String(), "│ ✗ syntax error")
+	})
+
+	t.Run("panic", func(t *testing.T) {
+		vt := termtest.New(36)
+		err := Run("Build", func(f *Frame) error {
+			panic("nil map")
+		}, testOptions(vt, statusTestEnv)...)
+
+		require.EqualError(t, err, "panic: nil map")
+		require.Equal(t, strings.Join([]string{
+			"┌── Build ─────────────────────────┐",
+			"│ ✗ panic: nil map                 │",
+			"└─────────────────────────────── ✗ ┘",
+			"",
+		}, "\n"), vt.Screen().String())
+	})
+}
@@ -0,0 +1,164 @@
+package frame
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pseudomuto/gooey/ansi"
+)
+
+const (
+	// TailKeep leaves the last lines of a tail viewport in place when the frame closes
+	TailKeep TailMode = iota
+	// TailDump replaces a tail viewport with every line written to the frame when it closes
+	TailDump
+)
+
+type (
+	// TailMode controls what a tail viewport shows once the frame closes. See WithTail.
+	TailMode int
+
+	// tailState holds the content lines of a frame opened with WithTail
+	tailState struct {
+		size    int
+		mode    TailMode
+		lines   []string // the lines in the viewport, or every line with TailDump
+		dropped int      // lines that scrolled out of the viewport and were discarded
+		partial bool     // the last line hasn't been terminated with a newline yet
+		drawn   int      // rows of the viewport currently drawn on a TTY
+	}
+)
+
+// WithTail limits the frame's content to a live viewport of its last lines, preceded by a
+// "… 243 more lines" indicator once older lines have scrolled out of view. This keeps noisy output,
+// like container builds or test runners, from flooding the terminal. When the frame closes, mode
+// decides whether the viewport is kept (TailKeep) or replaced by every line written (TailDump).
+//
+// On a TTY the viewport is redrawn in place as lines are written. When the output isn't a TTY, lines
+// are held back and the final viewport is written when the frame closes. ReplaceLine updates the last
+// line of the viewport, while dividers, ReplaceLineN, ReplaceBlock and nested frames end the current
+// viewport, leaving it in its final state, and start a new one below.
+//
+// Example:
+//
+//	f := frame.Open("docker build", frame.WithTail(5, frame.TailKeep))
+//	err := frame.Exec(f, exec.Command("docker", "build", "."))
+//	f.CloseWithError(err)
+//
+//	// While running shows: ┌── docker build ──────────────┐
+//	//                      │ … 243 more lines             │
+//	//                      │ #12 [build 4/6] RUN go mod … │
+//	//                      │ ...                          │
+func WithTail(lines int, mode TailMode) FrameOption {
+	return func(f *Frame) {
+		f.tail = &tailState{size: max(lines, 1), mode: mode}
+	}
+}
+
+// writeTail adds content to the viewport and redraws it. The caller must hold the frame's mutex.
+func (f *Frame) writeTail(content string) {
+	t := f.tail
+	endsWithNewline := strings.HasSuffix(content, "\n")
+	for i, line := range strings.Split(strings.TrimSuffix(content, "\n"), "\n") {
+		if i == 0 && t.partial {
+			t.lines[len(t.lines)-1] += line
+			continue
+		}
+
+		t.lines = append(t.lines, line)
+	}
+
+	t.partial = !endsWithNewline
+	f.redrawTail()
+}
+
+// replaceTailLine replaces the last line of the viewport and redraws it
+func (f *Frame) replaceTailLine(content string) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	t := f.tail
+	if len(t.lines) == 0 {
+		t.lines = append(t.lines, content)
+	} else {
+		t.lines[len(t.lines)-1] = content
+	}
+
+	t.partial = false
+	f.redrawTail()
+}
+
+// redrawTail scrolls lines out of the viewport and redraws it on a TTY. The caller must hold the
+// frame's mutex.
+func (f *Frame) redrawTail() {
+	t := f.tail
+	if t.mode == TailKeep && len(t.lines) > t.size {
+		t.dropped += len(t.lines) - t.size
+		t.lines = append([]string(nil), t.lines[len(t.lines)-t.size:]...)
+	}
+
+	if f.env.IsTTY() {
+		f.drawTail(t.viewport())
+	}
+}
+
+// finishTail leaves the viewport in its final state and starts a new, empty one below it
+func (f *Frame) finishTail() {
+	if f.tail == nil {
+		return
+	}
+
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	t := f.tail
+	if len(t.lines) == 0 {
+		return
+	}
+
+	lines := t.viewport()
+	if t.mode == TailDump {
+		lines = t.lines
+	}
+
+	f.drawTail(lines)
+	*t = tailState{size: t.size, mode: t.mode}
+}
+
+// drawTail renders lines as the viewport, replacing the rows drawn previously. The caller must hold the
+// frame's mutex.
+func (f *Frame) drawTail(lines []string) {
+	t := f.tail
+	if t.drawn == 0 || !f.env.IsTTY() {
+		var out strings.Builder
+		for _, line := range lines {
+			out.WriteString(f.formatContentLine(line) + "\n")
+		}
+
+		f.emit(out.String())
+	} else {
+		// The cursor sits below the viewport, while replaceBlock expects it on the viewport's last row
+		f.emit(ansi.MoveCursorUp(1))
+		f.replaceBlock(t.drawn, lines)
+		f.emit("\n")
+	}
+
+	t.drawn = len(lines)
+}
+
+// viewport returns the lines to show: the last lines written, preceded by a count of the rest
+func (t *tailState) viewport() []string {
+	visible := t.lines[max(len(t.lines)-t.size, 0):]
+	hidden := t.dropped + len(t.lines) - len(visible)
+	if hidden == 0 {
+		return visible
+	}
+
+	noun := "lines"
+	if hidden == 1 {
+		noun = "line"
+	}
+
+	indicator := ansi.BrightBlack.Colorize(fmt.Sprintf("… %d more %s", hidden, noun))
+	return append([]string{indicator}, visible...)
+}
@@ -0,0 +1,154 @@
+package frame_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pseudomuto/gooey/ci"
+	. "github.com/pseudomuto/gooey/frame"
+	"github.com/pseudomuto/gooey/spinner"
+	"github.com/pseudomuto/gooey/terminal"
+	"github.com/pseudomuto/gooey/termtest"
+	"github.com/stretchr/testify/require"
+)
+
+// tailTestEnv is the terminal the frames in these tests are opened in
+var tailTestEnv = terminal.Environment{Width: 30, TTY: terminal.TTYOn, Color: terminal.ColorNone, CI: ci.None}
+
+func TestFrameWithTail(t *testing.T) {
+	vt := termtest.New(30)
+	f := Open("Build", testOptions(vt, tailTestEnv, WithTail(2, TailKeep))...)
+
+	f.Println("step 1")
+	require.Equal(t, strings.Join([]string{
+		"┌── Build ───────────────────┐",
+		"│ step 1                     │",
+		"",
+	}, "\n"), vt.Screen().String())
+
+	f.Print("step 2\nstep")
+	f.Println(" 3")
+	f.Println("step 4")
+	f.ReplaceLine("step 4 (done)")
+	require.Equal(t, strings.Join([]string{
+		"┌── Build ───────────────────┐",
+		"│ … 2 more lines             │",
+		"│ step 3                     │",
+		"│ step 4 (done)              │",
+		"",
+	}, "\n"), vt.Screen().String())
+
+	f.Close()
+	require.Equal(t, strings.Join([]string{
+		"┌── Build ───────────────────┐",
+		"│ … 2 more lines             │",
+		"│ step 3                     │",
+		"│ step 4 (done)              │",
+		"└────────────────────────────┘",
+		"",
+	}, "\n"), vt.Screen().String())
+}
+
+func TestFrameWithTailDump(t *testing.T) {
+	vt := termtest.New(30)
+	f := Open("Build", testOptions(vt, tailTestEnv, WithTail(1, TailDump))...)
+	f.Println("step 1")
+	f.Println("step 2")
+	f.Println("step 3")
+	require.Contains(t, vt.Screen().String(), "│ … 2 more lines             │\n│ step 3 ")
+
+	f.Close()
+	require.Equal(t, strings.Join([]string{
+		"┌── Build ───────────────────┐",
+		"│ step 1                     │",
+		"│ step 2                     │",
+		"│ step 3                     │",
+		"└────────────────────────────┘",
+		"",
+	}, "\n"), vt.Screen().String())
+}
+
+func TestFrameWithTailEndsAtNestedFrames(t *testing.T) {
+	vt := termtest.New(30)
+	options := testOptions(vt, tailTestEnv)
+	outer := Open("Build", append(options, WithTail(1, TailKeep))...)
+	outer.Println("step 1")
+	outer.Println("step 2")
+
+	inner := Open("Test", options...)
+	inner.Println("ok")
+	inner.Close()
+
+	outer.Println("step 3")
+	outer.Close()
+
+	require.Equal(t, strings.Join([]string{
+		"┌── Build ───────────────────┐",
+		"│ … 1 more line              │",
+		"│ step 2                     │",
+		"│  ┌── Test ───────────────┐ │",
+		"│  │ ok                    │ │",
+		"│  └───────────────────────┘ │",
+		"│ step 3                     │",
+		"└────────────────────────────┘",
+		"",
+	}, "\n"), vt.Screen().String())
+}
+
+func TestFrameWithTailWithoutTTY(t *testing.T) {
+	var buf bytes.Buffer
+	env := terminal.Environment{Width: 30, TTY: terminal.TTYOff, Color: terminal.ColorNone, CI: ci.None}
+	f := Open("Build", testOptions(&buf, env, WithTail(2, TailKeep))...)
+
+	for _, line := range []string{"step 1", "step 2", "step 3"} {
+		f.Println(line)
+	}
+
+	require.Equal(t, "┌── Build ───────────────────┐\n", buf.String())
+
+	f.Close()
+	require.Equal(t, strings.Join([]string{
+		"┌── Build ───────────────────┐",
+		"│ … 1 more line              │",
+		"│ step 2                     │",
+		"│ step 3                     │",
+		"└────────────────────────────┘",
+		"",
+	}, "\n"), buf.String())
+}
+
+func TestFrameWithTailAndSpinner(t *testing.T) {
+	vt := termtest.New(30)
+	clk := newTestClock()
+	f := Open("Build", testOptions(vt, tailTestEnv, WithClock(clk), WithTail(2, TailKeep))...)
+
+	s := spinner.New("waiting",
+		spinner.WithOutput(f),
+		spinner.WithClock(clk),
+		spinner.WithTerminal(terminal.Environment{Width: 30, TTY: terminal.TTYOn, Color: terminal.ColorNone, CI: ci.None}))
+	s.Start()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for range 100 {
+			clk.Advance(100 * time.Millisecond)
+		}
+	}()
+
+	for i := range 100 {
+		f.Println("step %d", i)
+		if i%10 == 0 {
+			f.Divider("")
+		}
+	}
+
+	<-done
+	s.Stop()
+	f.Close()
+
+	require.Contains(t, vt.Screen().String(), "✓ waiting")
+	require.True(t, strings.HasSuffix(vt.Screen().String(), "└───────────────────── (10s) ┘\n"))
+}
@@ -0,0 +1,87 @@
+package frame
+
+import (
+	"github.com/pseudomuto/gooey/ansi"
+)
+
+// SetTitle replaces the frame's title. On a TTY the opening border is redrawn in place; otherwise, or
+// once the opening border has scrolled out of view, a status line with the new title is printed
+// inside the frame instead.
+//
+// Example:
+//
+//	f := frame.Open("Deploying")
+//	for i, svc := range services {
+//		f.SetTitle(fmt.Sprintf("Deploying %s", svc.Name))
+//		deploy(svc)
+//	}
+func (f *Frame) SetTitle(title string) {
+	f.titleMutex.Lock()
+	f.title = title
+	f.titleMutex.Unlock()
+
+	f.redrawOpen()
+}
+
+// SetBadge shows a short status at the right of the frame's opening border, such as a step counter.
+// An empty badge removes it. Like SetTitle, the border is redrawn in place on a TTY, and a status line is
+// printed otherwise.
+//
+// Example:
+//
+//	f := frame.Open("Deploying")
+//	for i, svc := range services {
+//		f.SetBadge(fmt.Sprintf("%d/%d services", i+1, len(services)))
+//		deploy(svc)
+//	}
+//
+//	// Shows: ┌── Deploying ─────────── 3/7 services ─┐
+func (f *Frame) SetBadge(badge string) {
+	f.titleMutex.Lock()
+	f.badge = badge
+	f.titleMutex.Unlock()
+
+	f.redrawOpen()
+}
+
+// redrawOpen re-renders the opening border in place when possible, and prints a status line with the
+// title and badge otherwise
+func (f *Frame) redrawOpen() {
+	if !f.stack.contains(f) || f.redrawBorder() {
+		return
+	}
+
+	color := f.renderContext().Color
+	f.titleMutex.RLock()
+	status := color.Sprint("»") + " " + f.title
+	if f.badge != "" {
+		status += " — " + f.badge
+	}
+	f.titleMutex.RUnlock()
+
+	f.Println("%s", status)
+}
+
+// redrawBorder re-renders the opening border in place, reporting false when that isn't possible
+// because the output isn't a TTY or the border has scrolled out of view
+func (f *Frame) redrawBorder() bool {
+	if !f.env.IsTTY() {
+		return false
+	}
+
+	ctx := f.renderContext()
+	border := f.env.ApplyProfile(ctx.line(f.renderer.Open(ctx)))
+
+	var redrawn bool
+	_ = f.deliver(func() string {
+		if f.rows >= f.env.Rows() {
+			return ""
+		}
+
+		// The cursor is restored afterwards, so rows written since the frame opened stay as they are
+		redrawn = true
+		return ansi.SaveCursor + ansi.MoveCursorUp(f.rows) + "\r" + ansi.ClearLine + border + ansi.RestoreCursor
+	})
+
+	return redrawn
+}
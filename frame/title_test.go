@@ -0,0 +1,82 @@
+package frame_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/pseudomuto/gooey/ci"
+	. "github.com/pseudomuto/gooey/frame"
+	"github.com/pseudomuto/gooey/terminal"
+	"github.com/pseudomuto/gooey/termtest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFrameSetTitleAndBadge(t *testing.T) {
+	tests := []struct {
+		name     string
+		style    FrameStyle
+		expected []string
+	}{
+		{
+			name:  "box",
+			style: Box,
+			expected: []string{
+				"┌── Deploying api ────── 3/7 done ─┐",
+				"│ starting                         │",
+				"│  ┌── Migrate ──────────────────┐ │",
+				"│  └─────────────────────────────┘ │",
+				"└──────────────────────────────────┘",
+				"",
+			},
+		},
+		{
+			name:  "bracket",
+			style: Bracket,
+			expected: []string{
+				"┌── Deploying api ── 3/7 done",
+				"│ starting",
+				"│  ┌── Migrate",
+				"│  └──",
+				"└──",
+				"",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vt := termtest.New(36)
+			env := terminal.Environment{Width: 36, Height: 24, TTY: terminal.TTYOn, Color: terminal.ColorNone, CI: ci.None}
+			options := testOptions(vt, env, WithStyle(tt.style))
+			f := Open("Deploying", options...)
+			f.Println("starting")
+			inner := Open("Migrate", options...)
+
+			f.SetTitle("Deploying api")
+			f.SetBadge("3/7 done")
+
+			// The cursor is restored after redrawing, so output carries on below
+			inner.Close()
+			f.Close()
+
+			require.Equal(t, strings.Join(tt.expected, "\n"), vt.Screen().String())
+		})
+	}
+}
+
+func TestFrameSetBadgeWithoutTTY(t *testing.T) {
+	var buf bytes.Buffer
+	env := terminal.Environment{Width: 36, TTY: terminal.TTYOff, Color: terminal.ColorNone, CI: ci.None}
+	f := Open("Deploying", testOptions(&buf, env)...)
+
+	f.SetBadge("3/7 services")
+	f.Close()
+
+	require.Equal(t, strings.Join([]string{
+		"┌── Deploying ─────────────────────┐",
+		"│ » Deploying — 3/7 services       │",
+		"└──────────────────────────────────┘",
+		"",
+	}, "\n"), buf.String())
+}
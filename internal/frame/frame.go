@@ -9,7 +9,9 @@ import (
 	"strings"
 
 	"github.com/pseudomuto/gooey/ansi"
+	"github.com/pseudomuto/gooey/ci"
 	"github.com/pseudomuto/gooey/frame"
+	"github.com/pseudomuto/gooey/terminal"
 )
 
 // FrameReplacer interface allows frames to update lines in place.
@@ -24,6 +26,8 @@ type FrameReplacer interface {
 // FrameAware provides common frame integration functionality for components
 type FrameAware struct {
 	output      io.Writer
+	env         terminal.Environment
+	ci          ci.Provider // the environment's CI provider, resolved when the environment is set
 	inFrame     bool
 	firstRender bool
 }
@@ -57,8 +61,11 @@ type FrameAware struct {
 // The frame-aware utility enables components to work seamlessly in both
 // standalone and frame contexts with optimal rendering strategies.
 func NewFrameAware(output io.Writer) *FrameAware {
+	env := terminal.Default()
 	return &FrameAware{
 		output:      output,
+		env:         env,
+		ci:          env.CIProvider(),
 		inFrame:     IsFrameWriter(output),
 		firstRender: true,
 	}
@@ -110,11 +117,46 @@ func (fa *FrameAware) SetOutput(output io.Writer) {
 	fa.inFrame = IsFrameWriter(output)
 }
 
+// Environment returns the terminal environment used for rendering.
+func (fa *FrameAware) Environment() terminal.Environment {
+	return fa.env
+}
+
+// SetEnvironment updates the terminal environment used for rendering. Colour and style sequences are
+// stripped from rendered content when the environment disables colour.
+func (fa *FrameAware) SetEnvironment(env terminal.Environment) {
+	fa.env = env
+	fa.ci = env.CIProvider()
+}
+
+// Width returns the number of columns available to a rendered line: the frame's content width when the
+// output is a frame that reports one, and the terminal width otherwise.
+func (fa *FrameAware) Width() int {
+	if cw, ok := fa.output.(interface{ ContentWidth() int }); ok {
+		return cw.ContentWidth()
+	}
+
+	return fa.env.Columns()
+}
+
+// HideCursor hides the cursor while an animation runs and returns a function that shows it again.
+// Nothing is written when the environment is not a TTY, since the output isn't an interactive terminal.
+// Hidden cursors are tracked for cleanup, so they're restored if the process is interrupted.
+func (fa *FrameAware) HideCursor() (show func()) {
+	if !fa.env.IsTTY() {
+		return func() {}
+	}
+
+	return terminal.HideCursor(fa.output)
+}
+
 // RenderContent renders content appropriately for frame or non-frame context
 func (fa *FrameAware) RenderContent(renderFunc func() string) {
-	content := renderFunc()
+	content := fa.env.ApplyProfile(renderFunc())
 
-	if fa.inFrame {
+	if fa.ci != nil {
+		fa.renderInCI(content)
+	} else if fa.inFrame {
 		fa.renderInFrame(content)
 	} else {
 		fa.renderStandalone(content)
@@ -139,26 +181,63 @@ func (fa *FrameAware) renderInFrame(content string) {
 	}
 }
 
-// renderStandalone renders content for non-frame context with cursor control
+// renderInCI prints the first render as a line of the CI log and drops the rest, since CI logs can't be
+// updated in place and would otherwise record every animation frame
+func (fa *FrameAware) renderInCI(content string) {
+	if fa.firstRender {
+		fmt.Fprintln(fa.output, content)
+		fa.firstRender = false
+	}
+}
+
+// renderStandalone renders content for non-frame context with cursor control, or as new lines when the
+// output isn't a terminal
 func (fa *FrameAware) renderStandalone(content string) {
 	if fa.firstRender {
 		// First render: just print the content
 		fmt.Fprint(fa.output, content)
 		fa.firstRender = false
+	} else if !fa.env.IsTTY() {
+		// Without a terminal to update in place, the update is appended as a new line
+		fa.appendLine(content)
 	} else {
 		// Subsequent renders: use carriage return and clear line for in-place update
 		fmt.Fprint(fa.output, "\r"+ansi.ClearLine+content)
 	}
 }
 
-// RenderFinal renders final content with completion handling
+// appendLine writes content on a new line after the one written by the previous render, keeping the
+// newline and content as separate writes so that wrapping writers (e.g. IndentedWriter) can decorate
+// the latter
+func (fa *FrameAware) appendLine(content string) {
+	if !fa.firstRender {
+		fmt.Fprint(fa.output, "\n")
+	}
+
+	fa.firstRender = false
+	fmt.Fprint(fa.output, content)
+}
+
+// RenderFinal renders final content with completion handling. In a CI log, or when the output isn't a
+// terminal, it's printed as a new line after the one printed by the previous render, rather than
+// replacing it.
 func (fa *FrameAware) RenderFinal(renderFunc func() string) {
-	content := renderFunc()
+	content := fa.env.ApplyProfile(renderFunc())
 
-	if fa.inFrame {
+	if fa.ci != nil {
+		fa.firstRender = false
+		if fa.inFrame {
+			fmt.Fprintln(fa.output, content)
+		} else {
+			// Standalone components end their final line themselves
+			fmt.Fprint(fa.output, content)
+		}
+	} else if fa.inFrame {
 		if frameReplacer, ok := fa.output.(FrameReplacer); ok {
 			frameReplacer.ReplaceLine("%s", content)
 		}
+	} else if !fa.env.IsTTY() {
+		fa.appendLine(content)
 	} else {
 		fmt.Fprint(fa.output, "\r"+ansi.ClearLine+content)
 	}
@@ -166,7 +245,11 @@ func (fa *FrameAware) RenderFinal(renderFunc func() string) {
 
 // RenderWithStringBuilder is a utility for components that need to render to a string first
 func (fa *FrameAware) RenderWithStringBuilder(renderFunc func(w io.Writer)) {
-	if fa.inFrame {
+	if fa.ci != nil {
+		var contentBuilder strings.Builder
+		renderFunc(&contentBuilder)
+		fa.renderInCI(fa.env.ApplyProfile(contentBuilder.String()))
+	} else if fa.inFrame {
 		fa.renderInFrameWithBuilder(renderFunc)
 	} else {
 		fa.renderStandaloneWithFunc(renderFunc)
@@ -177,7 +260,7 @@ func (fa *FrameAware) RenderWithStringBuilder(renderFunc func(w io.Writer)) {
 func (fa *FrameAware) renderInFrameWithBuilder(renderFunc func(w io.Writer)) {
 	var contentBuilder strings.Builder
 	renderFunc(&contentBuilder)
-	content := contentBuilder.String()
+	content := fa.env.ApplyProfile(contentBuilder.String())
 
 	if fa.firstRender {
 		fmt.Fprintln(fa.output, content)
@@ -193,11 +276,19 @@ func (fa *FrameAware) renderInFrameWithBuilder(renderFunc func(w io.Writer)) {
 
 // renderStandaloneWithFunc handles standalone rendering with cursor control
 func (fa *FrameAware) renderStandaloneWithFunc(renderFunc func(w io.Writer)) {
+	var contentBuilder strings.Builder
+	renderFunc(&contentBuilder)
+	content := fa.env.ApplyProfile(contentBuilder.String())
+
 	if fa.firstRender {
-		renderFunc(fa.output)
+		fmt.Fprint(fa.output, content)
 		fa.firstRender = false
+	} else if !fa.env.IsTTY() {
+		fa.appendLine(content)
 	} else {
+		// Keep the control sequence and content as separate writes so that wrapping writers
+		// (e.g. IndentedWriter) can pass the former through and decorate the latter
 		fmt.Fprint(fa.output, "\r"+ansi.ClearLine)
-		renderFunc(fa.output)
+		fmt.Fprint(fa.output, content)
 	}
 }
@@ -4,14 +4,23 @@ import (
 	"bytes"
 	"fmt"
 	"io"
+	"os"
 	"strings"
 	"testing"
 
 	"github.com/pseudomuto/gooey/ansi"
+	"github.com/pseudomuto/gooey/ci"
 	"github.com/pseudomuto/gooey/frame"
+	"github.com/pseudomuto/gooey/terminal"
 	"github.com/stretchr/testify/require"
 )
 
+// TestMain renders as on a local terminal, even when the tests run in CI
+func TestMain(m *testing.M) {
+	terminal.SetDefault(terminal.Environment{CI: ci.None})
+	os.Exit(m.Run())
+}
+
 // mockFrameReplacer implements both io.Writer and FrameReplacer for testing
 type mockFrameReplacer struct {
 	*bytes.Buffer
@@ -152,6 +161,7 @@ func TestFrameAware_MarkRendered(t *testing.T) {
 func TestFrameAware_RenderContent_Standalone(t *testing.T) {
 	buf := &bytes.Buffer{}
 	fa := NewFrameAware(buf)
+	fa.SetEnvironment(terminal.Environment{TTY: terminal.TTYOn, CI: ci.None})
 
 	// First render
 	fa.RenderContent(func() string {
@@ -197,6 +207,7 @@ func TestFrameAware_RenderContent_Frame(t *testing.T) {
 func TestFrameAware_RenderFinal_Standalone(t *testing.T) {
 	buf := &bytes.Buffer{}
 	fa := NewFrameAware(buf)
+	fa.SetEnvironment(terminal.Environment{TTY: terminal.TTYOn, CI: ci.None})
 
 	fa.RenderFinal(func() string {
 		return "final content"
@@ -223,6 +234,7 @@ func TestFrameAware_RenderFinal_Frame(t *testing.T) {
 func TestFrameAware_RenderWithStringBuilder_Standalone(t *testing.T) {
 	buf := &bytes.Buffer{}
 	fa := NewFrameAware(buf)
+	fa.SetEnvironment(terminal.Environment{TTY: terminal.TTYOn, CI: ci.None})
 
 	// First render
 	fa.RenderWithStringBuilder(func(w io.Writer) {
@@ -265,6 +277,45 @@ func TestFrameAware_RenderWithStringBuilder_Frame(t *testing.T) {
 	require.Equal(t, []interface{}{"builder content 2"}, mock.replaceLineCalls[0].args)
 }
 
+func TestFrameAware_RenderInCI(t *testing.T) {
+	env := terminal.Environment{TTY: terminal.TTYOff, Color: terminal.ColorNone, CI: ci.GitHubActions}
+
+	buf := &bytes.Buffer{}
+	fa := NewFrameAware(buf)
+	fa.SetEnvironment(env)
+
+	// Only the first render and the final content are printed, as separate lines
+	fa.RenderContent(func() string { return "first content" })
+	fa.RenderWithStringBuilder(func(w io.Writer) { fmt.Fprint(w, "second content") })
+	fa.RenderFinal(func() string { return "final content" })
+	require.Equal(t, "first content\nfinal content", buf.String())
+
+	mock := newMockFrameReplacer()
+	fa = NewFrameAware(mock)
+	fa.SetEnvironment(env)
+	fa.inFrame = true // Force frame mode for the mock
+
+	fa.RenderContent(func() string { return "first content" })
+	fa.RenderContent(func() string { return "second content" })
+	fa.RenderFinal(func() string { return "final content" })
+	require.Equal(t, "first content\nfinal content\n", mock.String())
+	require.Empty(t, mock.replaceLineCalls)
+}
+
+func TestFrameAware_RenderStandaloneWithoutTTY(t *testing.T) {
+	buf := &bytes.Buffer{}
+	fa := NewFrameAware(buf)
+	fa.SetEnvironment(terminal.Environment{TTY: terminal.TTYOff, Color: terminal.ColorNone, CI: ci.None})
+
+	// Updates can't be made in place, so each one is appended as a new line
+	fa.RenderContent(func() string { return "first content" })
+	fa.RenderWithStringBuilder(func(w io.Writer) { fmt.Fprint(w, "second content") })
+	fa.RenderFinal(func() string { return "final content" })
+	require.Equal(t, "first content\nsecond content\nfinal content", buf.String())
+	require.NotContains(t, buf.String(), "\r")
+	require.NotContains(t, buf.String(), ansi.ClearLine)
+}
+
 func TestFrameAware_RenderContent_FrameWithoutReplacer(t *testing.T) {
 	// Test frame writer that doesn't implement FrameReplacer
 	frameWithoutReplacer := frame.Open("test", frame.WithOutput(&bytes.Buffer{}))
@@ -282,6 +333,7 @@ func TestFrameAware_ComplexRenderingScenario(t *testing.T) {
 	// Test a complex scenario with multiple renders and state changes
 	buf := &bytes.Buffer{}
 	fa := NewFrameAware(buf)
+	fa.SetEnvironment(terminal.Environment{TTY: terminal.TTYOn, CI: ci.None})
 
 	// Initial render
 	fa.RenderContent(func() string {
@@ -13,10 +13,14 @@ import (
 	"github.com/mattn/go-runewidth"
 )
 
-// Default terminal width if detection fails
-const defaultTerminalWidth = 120
+const (
+	// Default terminal width if detection fails
+	defaultTerminalWidth = 120
+	// Default terminal height if detection fails
+	defaultTerminalHeight = 24
+)
 
-var ansiRegex = regexp.MustCompile(`\x1b\[[0-9;]*[a-zA-Z]`)
+var ansiRegex = regexp.MustCompile(`\x1b\[[0-9;?]*[a-zA-Z]`)
 
 type winsize struct {
 	Row    uint16
@@ -36,6 +40,32 @@ type winsize struct {
 //		fmt.Println("Terminal is too narrow for optimal display")
 //	}
 func Width() int {
+	ws, ok := windowSize()
+	if !ok {
+		return defaultTerminalWidth
+	}
+
+	return int(ws.Col)
+}
+
+// Height returns the current terminal height in rows.
+// If the terminal height cannot be detected (e.g., when not running in a TTY),
+// it returns a default height of 24 rows.
+//
+// Example:
+//
+//	height := term.Height()
+//	visible := lines[max(len(lines)-height, 0):]
+func Height() int {
+	ws, ok := windowSize()
+	if !ok || ws.Row == 0 {
+		return defaultTerminalHeight
+	}
+
+	return int(ws.Row)
+}
+
+func windowSize() (winsize, bool) {
 	var ws winsize
 	retCode, _, errno := syscall.Syscall(syscall.SYS_IOCTL,
 		uintptr(syscall.Stdin),
@@ -43,10 +73,10 @@ func Width() int {
 		uintptr(unsafe.Pointer(&ws)))
 
 	if int(retCode) == -1 || errno != 0 {
-		return defaultTerminalWidth
+		return ws, false
 	}
 
-	return int(ws.Col)
+	return ws, true
 }
 
 // IsTTY returns true if the current environment supports TTY operations and ANSI escape sequences.
@@ -96,6 +126,18 @@ func StripCodes(s string) string {
 	return ansiRegex.ReplaceAllString(s, "")
 }
 
+// IsControlSequence reports whether s is made up entirely of ANSI escape sequences, such as a cursor
+// movement or a request to hide the cursor. Writers use this to pass such output through untouched.
+//
+// Examples:
+//
+//	IsControlSequence("\033[?25l")         // Returns: true
+//	IsControlSequence("\033[31mhi\033[0m") // Returns: false
+//	IsControlSequence("")                  // Returns: false
+func IsControlSequence(s string) bool {
+	return s != "" && StripCodes(s) == ""
+}
+
 // TruncateString truncates a string to the specified printable width while preserving ANSI escape sequences.
 // The function correctly handles Unicode characters, emojis, and ANSI color codes.
 // If maxWidth is 0 or negative, it returns an empty string.
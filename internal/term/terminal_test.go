@@ -9,7 +9,10 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
-const defaultTerminalWidth = 120
+const (
+	defaultTerminalWidth  = 120
+	defaultTerminalHeight = 24
+)
 
 func TestWidth(t *testing.T) {
 	width := Width()
@@ -22,6 +25,16 @@ func TestWidth(t *testing.T) {
 	}
 }
 
+func TestHeight(t *testing.T) {
+	height := Height()
+
+	if isatty.IsTerminal(os.Stdin.Fd()) {
+		require.Positive(t, height, "expected to get a terminal height")
+	} else {
+		require.Equal(t, defaultTerminalHeight, height, "expected to get default height when not in a TTY")
+	}
+}
+
 func TestPrintableWidth(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -133,6 +146,11 @@ func TestStripCodes(t *testing.T) {
 			input:    "👩‍👩‍👧‍👦",
 			expected: "👩‍👩‍👧‍👦",
 		},
+		{
+			name:     "string with private mode sequences",
+			input:    "\033[?25lhidden\033[?25h",
+			expected: "hidden",
+		},
 	}
 
 	for _, tt := range tests {
@@ -142,6 +160,13 @@ func TestStripCodes(t *testing.T) {
 	}
 }
 
+func TestIsControlSequence(t *testing.T) {
+	require.True(t, IsControlSequence("\033[?25l"))
+	require.True(t, IsControlSequence("\033[1A\033[2K"))
+	require.False(t, IsControlSequence("\033[31mhi\033[0m"))
+	require.False(t, IsControlSequence(""))
+}
+
 func TestTruncateString(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -9,6 +9,7 @@ import (
 
 	"github.com/pseudomuto/gooey/ansi"
 	internalframe "github.com/pseudomuto/gooey/internal/frame"
+	"github.com/pseudomuto/gooey/internal/term"
 )
 
 // IndentedWriter wraps an io.Writer to add consistent indentation to output.
@@ -83,6 +84,7 @@ func (iw *IndentedWriter) Write(p []byte) (n int, err error) {
 	// 3. Empty content - pass through without modification
 	if strings.HasPrefix(content, "\r") ||
 		strings.Contains(content, ansi.ClearLine) ||
+		term.IsControlSequence(content) || // e.g. hiding or showing the cursor
 		content == "\n" || // Standalone newline from spinner completion
 		strings.TrimSpace(content) == "" {
 		// Pass through control sequences, standalone newlines, and empty content without indentation
@@ -7,11 +7,13 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"sync"
 	"time"
 
 	"github.com/pseudomuto/gooey/ansi"
+	"github.com/pseudomuto/gooey/clock"
 	"github.com/pseudomuto/gooey/internal/frame"
-	"github.com/pseudomuto/gooey/internal/term"
+	"github.com/pseudomuto/gooey/terminal"
 )
 
 const (
@@ -29,12 +31,21 @@ type (
 		color                  ansi.Color
 		width                  int
 		frameAware             *frame.FrameAware
+		clock                  clock.Clock
 		startTime              time.Time
 		message                string
 		completed              bool
 		failed                 bool    // tracks if progress ended in failure
+		interrupted            bool    // tracks if progress was finished early by an interrupt
 		lastRenderedPercentage float64 // tracks last rendered percentage for frame mode
 		renderer               ProgressRenderer
+		showCursor             func()
+		untrack                func()
+
+		// renderMutex serialises state changes with the renders that show them, since Interrupt runs on
+		// the signal handler's goroutine. mutex guards the state itself, for the getters.
+		renderMutex sync.Mutex
+		mutex       sync.RWMutex
 	}
 
 	ProgressOption func(*Progress)
@@ -63,7 +74,7 @@ func New(title string, total int, options ...ProgressOption) *Progress {
 		color:                  defaultProgressColor,
 		width:                  defaultProgressWidth,
 		frameAware:             frame.NewFrameAware(defaultProgressOutput),
-		startTime:              time.Now(),
+		clock:                  clock.Default(),
 		message:                "",
 		completed:              false,
 		lastRenderedPercentage: -1, // Initialize to -1 to ensure first render
@@ -74,6 +85,7 @@ func New(title string, total int, options ...ProgressOption) *Progress {
 		option(p)
 	}
 
+	p.startTime = p.clock.Now()
 	return p
 }
 
@@ -84,12 +96,13 @@ func New(title string, total int, options ...ProgressOption) *Progress {
 //
 //	p.Update(50, "Processing item 50 of 100")
 func (p *Progress) Update(current int, message string) {
-	if p.completed {
+	p.renderMutex.Lock()
+	defer p.renderMutex.Unlock()
+
+	if !p.change(func() { p.current, p.message = current, message }) {
 		return
 	}
 
-	p.current = current
-	p.message = message
 	p.render()
 }
 
@@ -100,12 +113,13 @@ func (p *Progress) Update(current int, message string) {
 //
 //	p.Increment("Processed another item")
 func (p *Progress) Increment(message string) {
-	if p.completed {
+	p.renderMutex.Lock()
+	defer p.renderMutex.Unlock()
+
+	if !p.change(func() { p.current, p.message = p.current+1, message }) {
 		return
 	}
 
-	p.current++
-	p.message = message
 	p.render()
 }
 
@@ -116,26 +130,24 @@ func (p *Progress) Increment(message string) {
 //
 //	p.Complete("All tasks completed successfully!")
 func (p *Progress) Complete(message string) {
-	if p.completed {
+	p.renderMutex.Lock()
+	defer p.renderMutex.Unlock()
+
+	changed := p.change(func() {
+		p.current = p.total
+		if message != "" {
+			p.message = message
+		}
+		p.completed = true
+	})
+	if !changed {
 		return
 	}
 
-	p.current = p.total
-	if message != "" {
-		p.message = message
-	}
-	p.completed = true
 	p.render()
 
 	// Show success symbol and add newline
-	p.frameAware.RenderFinal(func() string {
-		return fmt.Sprintf("%s %s", ansi.CheckMark.Colorize(ansi.Green), p.message)
-	})
-
-	// Add newline if not in frame
-	if !p.frameAware.InFrame() {
-		fmt.Fprint(p.frameAware.Output(), "\n")
-	}
+	p.renderFinal(ansi.CheckMark.Colorize(ansi.Green) + " " + p.message)
 }
 
 // Start begins showing the progress bar. This renders the initial state of the progress bar.
@@ -147,6 +159,9 @@ func (p *Progress) Complete(message string) {
 //	p := progress.New("Upload", 100)
 //	p.Start() // Shows the initial progress bar
 func (p *Progress) Start() {
+	p.renderMutex.Lock()
+	defer p.renderMutex.Unlock()
+
 	p.render()
 }
 
@@ -162,25 +177,64 @@ func (p *Progress) Start() {
 //		p.Fail("Upload failed: " + err.Error())
 //	}
 func (p *Progress) Fail(message string) {
-	if p.completed || p.failed {
+	p.renderMutex.Lock()
+	defer p.renderMutex.Unlock()
+
+	changed := p.change(func() {
+		if message != "" {
+			p.message = message
+		}
+		p.failed = true
+		p.completed = true // Prevent further updates
+	})
+	if !changed {
 		return
 	}
 
-	if message != "" {
-		p.message = message
-	}
-	p.failed = true
-	p.completed = true // Prevent further updates
 	p.render()
 
 	// Show failure symbol and add newline
-	p.frameAware.RenderFinal(func() string {
-		return fmt.Sprintf("%s %s", ansi.CrossMark.Colorize(ansi.Red), p.message)
-	})
+	p.renderFinal(ansi.CrossMark.Colorize(ansi.Red) + " " + p.message)
+}
 
-	// Add newline if not in frame
-	if !p.frameAware.InFrame() {
-		fmt.Fprint(p.frameAware.Output(), "\n")
+// Interrupt marks the progress as interrupted, leaving the bar at its current value. It is called by the
+// terminal cleanup manager when the process is interrupted (see terminal.HandleSignals) or panics. After
+// calling Interrupt, further Update/Increment/Complete calls will be ignored.
+//
+// Example:
+//
+//	p := progress.New("Upload", 100)
+//	p.Update(50, "Uploading...")
+//	p.Interrupt() // Shows: ⚠ Uploading... (interrupted)
+func (p *Progress) Interrupt() {
+	p.renderMutex.Lock()
+	defer p.renderMutex.Unlock()
+
+	if !p.change(func() { p.interrupted, p.completed = true, true }) {
+		return
+	}
+
+	message := p.message
+	if message == "" {
+		message = p.title
+	}
+
+	p.renderFinal(fmt.Sprintf("%s %s %s",
+		ansi.Warning.Colorize(ansi.Yellow), message, ansi.Yellow.Colorize("(interrupted)")))
+}
+
+// SetClock replaces the clock used to measure elapsed time, which is then measured from now.
+//
+// Example:
+//
+//	p := progress.New("Upload", 100)
+//	p.SetClock(clock.NewFake(time.Now()))
+func (p *Progress) SetClock(c clock.Clock) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if c != nil {
+		p.clock = c
+		p.startTime = c.Now()
 	}
 }
 
@@ -193,17 +247,55 @@ func (p *Progress) Fail(message string) {
 //	p := progress.New("Task", 100)
 //	p.SetOutput(&buf) // Redirect to buffer
 func (p *Progress) SetOutput(output io.Writer) {
+	p.renderMutex.Lock()
+	defer p.renderMutex.Unlock()
 	p.frameAware.SetOutput(output)
 }
 
+// change applies update to the progress state unless the progress has already finished, reporting
+// whether it was applied. Callers hold renderMutex, so the state can't change again before it's rendered.
+func (p *Progress) change(update func()) bool {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if p.completed {
+		return false
+	}
+
+	update()
+	return true
+}
+
 // render draws the current progress bar state to the output writer.
 // This method handles cursor positioning to update the progress bar in-place.
+// The first render of an unfinished bar hides the cursor and tracks the bar for cleanup.
 func (p *Progress) render() {
+	if p.untrack == nil && !p.completed {
+		p.showCursor = p.frameAware.HideCursor()
+		p.untrack = terminal.Track(p)
+	}
+
 	p.frameAware.RenderWithStringBuilder(func(w io.Writer) {
 		p.renderer.Render(p, w)
 	})
 }
 
+// renderFinal replaces the bar with its final line and restores the cursor
+func (p *Progress) renderFinal(content string) {
+	p.frameAware.RenderFinal(func() string {
+		return content
+	})
+
+	// Add newline if not in frame
+	if !p.frameAware.InFrame() {
+		fmt.Fprint(p.frameAware.Output(), "\n")
+	}
+
+	if p.untrack != nil {
+		p.untrack()
+		p.showCursor()
+	}
+}
+
 // WithColor sets the color for the progress bar.
 // The color applies to the filled portion of the progress indicator.
 //
@@ -259,13 +351,48 @@ func WithRenderer(renderer ProgressRenderer) ProgressOption {
 	}
 }
 
+// WithClock sets the clock used to measure elapsed time.
+// By default, progress bars use clock.Default(), which is the system clock unless overridden.
+//
+// Example:
+//
+//	fake := clock.NewFake(time.Now())
+//	p := progress.New("Task", 100, progress.WithClock(fake))
+//	fake.Advance(time.Second)
+//	p.Elapsed() // Returns exactly 1s
+func WithClock(c clock.Clock) ProgressOption {
+	return func(p *Progress) {
+		if c != nil {
+			p.clock = c
+		}
+	}
+}
+
+// WithTerminal overrides the terminal environment the progress bar renders into.
+// By default, progress bars use terminal.Default(), which detects the width, TTY status and colour
+// support from the running process unless overridden.
+//
+// Example:
+//
+//	env := terminal.Environment{Width: 80, Color: terminal.ColorNone}
+//	p := progress.New("Task", 100, progress.WithTerminal(env))
+func WithTerminal(env terminal.Environment) ProgressOption {
+	return func(p *Progress) {
+		p.frameAware.SetEnvironment(env)
+	}
+}
+
 // Current returns the current progress value.
 func (p *Progress) Current() int {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
 	return p.current
 }
 
 // Total returns the total progress value.
 func (p *Progress) Total() int {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
 	return p.total
 }
 
@@ -281,24 +408,34 @@ func (p *Progress) Total() int {
 //	p.SetTotal(fileSize)
 //	p.Update(bytesDownloaded, "Downloading...")
 func (p *Progress) SetTotal(total int) {
-	if p.completed {
-		return
-	}
-	p.total = total
+	p.change(func() { p.total = total })
 }
 
 // IsCompleted returns true if the progress has been marked as complete.
 func (p *Progress) IsCompleted() bool {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
 	return p.completed
 }
 
 // IsFailed returns true if the progress has been marked as failed.
 func (p *Progress) IsFailed() bool {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
 	return p.failed
 }
 
+// IsInterrupted returns true if the progress was finished early by an interrupt.
+func (p *Progress) IsInterrupted() bool {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+	return p.interrupted
+}
+
 // Percentage returns the current completion percentage as a float64.
 func (p *Progress) Percentage() float64 {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
 	if p.total == 0 {
 		return 0
 	}
@@ -307,11 +444,15 @@ func (p *Progress) Percentage() float64 {
 
 // Elapsed returns the time elapsed since the progress bar was created.
 func (p *Progress) Elapsed() time.Duration {
-	return time.Since(p.startTime)
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+	return p.clock.Since(p.startTime)
 }
 
 // Message returns the current progress message.
 func (p *Progress) Message() string {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
 	return p.message
 }
 
@@ -333,7 +474,7 @@ func (p *Progress) Width() int {
 // AvailableWidth calculates the available width for the progress section (60% of total).
 // This matches the three-section layout used by charRenderer.
 func (p *Progress) AvailableWidth() int {
-	totalWidth := term.Width()
+	totalWidth := p.frameAware.Environment().Columns()
 	if p.frameAware.InFrame() {
 		totalWidth = totalWidth - 6 // Account for frame borders and padding
 	}
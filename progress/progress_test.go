@@ -2,16 +2,27 @@ package progress_test
 
 import (
 	"bytes"
+	"os"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/pseudomuto/gooey/ansi"
+	"github.com/pseudomuto/gooey/ci"
+	"github.com/pseudomuto/gooey/clock"
 	"github.com/pseudomuto/gooey/internal/term"
 	. "github.com/pseudomuto/gooey/progress"
+	"github.com/pseudomuto/gooey/terminal"
+	"github.com/pseudomuto/gooey/termtest"
 	"github.com/stretchr/testify/require"
 )
 
+// TestMain renders as on a local terminal, even when the tests run in CI
+func TestMain(m *testing.M) {
+	terminal.SetDefault(terminal.Environment{CI: ci.None})
+	os.Exit(m.Run())
+}
+
 func TestProgressBasic(t *testing.T) {
 	var buf bytes.Buffer
 	p := New("Test Progress", 100, WithOutput(&buf))
@@ -206,6 +217,15 @@ func TestProgressElapsed(t *testing.T) {
 	require.GreaterOrEqual(t, elapsed, 10*time.Millisecond, "Elapsed time should be at least 10ms")
 }
 
+func TestProgressElapsedWithFakeClock(t *testing.T) {
+	fake := clock.NewFake(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	p := New("Test", 100, WithClock(fake))
+	require.Zero(t, p.Elapsed())
+
+	fake.Advance(1500 * time.Millisecond)
+	require.Equal(t, 1500*time.Millisecond, p.Elapsed())
+}
+
 func TestProgressZeroTotal(t *testing.T) {
 	p := New("Test", 0)
 
@@ -301,6 +321,47 @@ func TestProgressFailRendering(t *testing.T) {
 	require.Contains(t, output, ansi.Red.String())
 }
 
+func TestProgressInterrupt(t *testing.T) {
+	vt := termtest.New(80)
+	p := New("Upload", 100, WithOutput(vt), WithTerminal(terminal.Environment{Width: 80, TTY: terminal.TTYOn, CI: ci.None}))
+
+	p.Update(50, "Uploading...")
+	require.False(t, vt.CursorVisible())
+
+	terminal.Interrupt()
+	require.True(t, p.IsInterrupted())
+	require.True(t, p.IsCompleted())
+	require.False(t, p.IsFailed())
+	require.True(t, vt.CursorVisible())
+	require.Equal(t, "⚠ Uploading... (interrupted)\n", vt.Screen().String())
+
+	// Further updates are ignored
+	p.Update(75, "Still uploading")
+	p.Complete("Done")
+	require.Equal(t, "⚠ Uploading... (interrupted)\n", vt.Screen().String())
+}
+
+func TestProgressInterruptWhileUpdating(t *testing.T) {
+	var buf bytes.Buffer
+	env := terminal.Environment{Width: 80, TTY: terminal.TTYOff, Color: terminal.ColorNone, CI: ci.None}
+	p := New("Upload", 1000, WithOutput(&buf), WithTerminal(env))
+
+	// Interrupts arrive on the signal handler's goroutine while the caller keeps updating
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for range 1000 {
+			p.Increment("Uploading...")
+		}
+	}()
+
+	p.Interrupt()
+	<-done
+
+	require.True(t, p.IsInterrupted())
+	require.True(t, strings.HasSuffix(buf.String(), "(interrupted)\n"), buf.String())
+}
+
 func TestProgressSetOutput(t *testing.T) {
 	var buf1, buf2 bytes.Buffer
 	p := New("Test", 100, WithOutput(&buf1))
@@ -93,7 +93,7 @@ func (r rendererFunc) Render(p *Progress, w io.Writer) {
 // title (20%), progress bar (70%), and update text (10%).
 func (r *charRenderer) Render(p *Progress, w io.Writer) {
 	// Calculate total available width (depends on context)
-	totalWidth := term.Width()
+	totalWidth := p.frameAware.Environment().Columns()
 	if p.frameAware.InFrame() {
 		totalWidth = totalWidth - 6 // Account for frame borders and padding
 	}
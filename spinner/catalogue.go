@@ -0,0 +1,40 @@
+package spinner
+
+import "time"
+
+// Well-known spinner animations, each with the interval it looks best at. Use them with WithRenderer, or
+// build your own with NewFrames.
+var (
+	// Line spins a line through - \ | /
+	Line = NewFrames(130*time.Millisecond, "-", "\\", "|", "/")
+	// Bounce bounces a dot up and down a braille cell
+	Bounce = NewFrames(120*time.Millisecond, "⠁", "⠂", "⠄", "⠂")
+	// Pulse grows and shrinks a dot
+	Pulse = NewFrames(120*time.Millisecond, "∙", "•", "●", "•")
+	// Points moves a dot along three points
+	Points = NewFrames(125*time.Millisecond, "∙∙∙", "●∙∙", "∙●∙", "∙∙●")
+	// Arc sweeps an arc around a circle
+	Arc = NewFrames(100*time.Millisecond, "◜", "◠", "◝", "◞", "◡", "◟")
+	// GrowingBar grows and shrinks a vertical bar
+	GrowingBar = NewFrames(120*time.Millisecond, "▁", "▃", "▄", "▅", "▆", "▇", "█", "▇", "▆", "▅", "▄", "▃")
+	// BrailleCircle moves a dot around a two-cell braille circle
+	BrailleCircle = NewFrames(80*time.Millisecond, "⢎ ", "⠎⠁", "⠊⠑", "⠈⠱", " ⡱", "⢀⡰", "⢄⡠", "⢆⡀")
+	// Triangle rotates a triangle through the corners of a square
+	Triangle = NewFrames(50*time.Millisecond, "◢", "◣", "◤", "◥")
+	// SquareCorners rotates a quarter through a square
+	SquareCorners = NewFrames(180*time.Millisecond, "◰", "◳", "◲", "◱")
+	// Star twinkles a star
+	Star = NewFrames(70*time.Millisecond, "✶", "✸", "✹", "✺", "✹", "✷")
+	// Toggle flips between two states
+	Toggle = NewFrames(250*time.Millisecond, "⊶", "⊷")
+	// BouncingBall bounces a ball between two walls
+	BouncingBall = NewFrames(80*time.Millisecond,
+		"( ●    )", "(  ●   )", "(   ●  )", "(    ● )", "(     ●)",
+		"(    ● )", "(   ●  )", "(  ●   )", "( ●    )", "(●     )")
+	// Moon shows the phases of the moon
+	Moon = NewFrames(80*time.Millisecond, "🌑", "🌒", "🌓", "🌔", "🌕", "🌖", "🌗", "🌘")
+	// Earth spins the globe
+	Earth = NewFrames(180*time.Millisecond, "🌍", "🌎", "🌏")
+	// Hourglass turns an hourglass over
+	Hourglass = NewFrames(500*time.Millisecond, "⏳", "⌛")
+)
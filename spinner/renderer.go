@@ -3,17 +3,24 @@ package spinner
 import (
 	"fmt"
 	"io"
+	"strings"
+	"time"
 
 	"github.com/pseudomuto/gooey/ansi"
+	"github.com/pseudomuto/gooey/internal/term"
 )
 
 var (
 	// Dots renderer uses spinning dots for the spinner animation
-	Dots = &dotsRenderer{}
-	// Clock renderer uses clock icons for the spinner animation
-	Clock = &clockRenderer{}
-	// Arrow renderer uses arrow icons for the spinner animation
-	Arrow = &arrowRenderer{}
+	Dots = NewFrames(defaultSpinnerInterval,
+		ansi.Spinner1.String(), ansi.Spinner2.String(), ansi.Spinner3.String(), ansi.Spinner4.String(),
+		ansi.Spinner5.String(), ansi.Spinner6.String(), ansi.Spinner7.String(), ansi.Spinner8.String())
+	// Clock renderer uses a 4-frame subset of the dots for a slower-looking animation
+	Clock = NewFrames(defaultSpinnerInterval,
+		ansi.Spinner1.String(), ansi.Spinner3.String(), ansi.Spinner5.String(), ansi.Spinner7.String())
+	// Arrow renderer uses arrows rotating clockwise (→↓←↑)
+	Arrow = NewFrames(defaultSpinnerInterval,
+		ansi.ArrowRight.String(), ansi.ArrowDown.String(), ansi.ArrowLeft.String(), ansi.ArrowUp.String())
 )
 
 type (
@@ -22,12 +29,56 @@ type (
 		Render(s *Spinner, frame int, w io.Writer)
 	}
 
-	// RenderFunc is a function type that implements SpinnerRenderer
+	// RenderFunc is a function type that implements SpinnerRenderer. Renderers run while the spinner is
+	// locked for reading, so they mustn't call methods that lock it, such as Message. Use
+	// RenderContextFunc to read the spinner's state instead.
 	RenderFunc func(s *Spinner, frame int, w io.Writer)
 
-	dotsRenderer  struct{}
-	clockRenderer struct{}
-	arrowRenderer struct{}
+	// RenderContextFunc is a function type that implements SpinnerRenderer, drawing an animation frame
+	// from a snapshot of the spinner's state.
+	//
+	// Example:
+	//
+	//	// Right-align an elapsed time counter after the message
+	//	timer := spinner.RenderContextFunc(func(ctx spinner.RenderContext, w io.Writer) {
+	//		elapsed := ctx.ElapsedText()
+	//		gap := max(ctx.Width-len(ctx.Message)-len(elapsed)-2, 1)
+	//		fmt.Fprintf(w, "%s %s%s%s", ctx.Color.Colorize("●"), ctx.Message, strings.Repeat(" ", gap), elapsed)
+	//	})
+	//
+	//	s := spinner.New("Deploying...", spinner.WithRenderer(timer))
+	RenderContextFunc func(ctx RenderContext, w io.Writer)
+
+	// RenderContext is a snapshot of a spinner's state for rendering one animation frame.
+	RenderContext struct {
+		// Message is the spinner's current message.
+		Message string
+		// Elapsed is the time since the spinner started.
+		Elapsed time.Duration
+		// Frame is the index of the animation frame, counting up from 0 for as long as the spinner runs.
+		Frame int
+		// Color is the spinner's colour for this frame, rotating unless WithColor was given.
+		Color ansi.Color
+		// Width is the number of columns available to the rendered line: the content width of the frame
+		// the spinner writes to, or the terminal width.
+		Width int
+		// InFrame reports whether the spinner writes to a frame.
+		InFrame bool
+	}
+
+	// Frames is a SpinnerRenderer that cycles through a sequence of frames, each shown for its interval.
+	// Frames are padded to the width of the widest one, so the message doesn't shift when frames have
+	// different widths (e.g. multi-character frames or wide runes such as emoji).
+	Frames struct {
+		frames   []string
+		interval time.Duration
+		width    int
+	}
+
+	// intervalRenderer is implemented by renderers with a recommended animation interval
+	intervalRenderer interface {
+		Interval() time.Duration
+	}
 )
 
 // Render executes the render function for the spinner
@@ -35,45 +86,69 @@ func (f RenderFunc) Render(s *Spinner, frame int, w io.Writer) {
 	f(s, frame, w)
 }
 
-// Render implements SpinnerRenderer for the dots renderer.
-// Uses 8-frame braille spinner icons with automatic color rotation.
-func (r *dotsRenderer) Render(s *Spinner, frame int, w io.Writer) {
-	icons := []ansi.Icon{
-		ansi.Spinner1, ansi.Spinner2, ansi.Spinner3, ansi.Spinner4,
-		ansi.Spinner5, ansi.Spinner6, ansi.Spinner7, ansi.Spinner8,
+// Render calls f with the spinner's render context for the animation frame.
+func (f RenderContextFunc) Render(s *Spinner, frame int, w io.Writer) {
+	f(s.renderContext(frame), w)
+}
+
+// ElapsedText returns the elapsed time formatted like the spinner's completion line, e.g. "1.25s".
+func (ctx RenderContext) ElapsedText() string {
+	return formatElapsed(ctx.Elapsed)
+}
+
+// NewFrames creates a renderer that cycles through frames, advancing every interval. Spinners using it
+// animate at that interval unless WithInterval is given. Frames may be several characters wide, and are
+// coloured with the spinner's colour.
+//
+// Example:
+//
+//	pong := spinner.NewFrames(80*time.Millisecond, "▐⠂       ▌", "▐ ⠂      ▌", "▐  ⠂     ▌")
+//	s := spinner.New("Waiting...", spinner.WithRenderer(pong))
+func NewFrames(interval time.Duration, frames ...string) *Frames {
+	if interval <= 0 {
+		interval = defaultSpinnerInterval
 	}
 
-	icon := icons[frame%len(icons)]
-	color := s.CurrentColor(frame)
-	coloredIcon := icon.Colorize(color)
+	width := 0
+	for _, frame := range frames {
+		width = max(width, term.PrintableWidth(frame))
+	}
 
-	fmt.Fprintf(w, "%s %s", coloredIcon, s.message)
+	return &Frames{frames: frames, interval: interval, width: width}
 }
 
-// Render implements SpinnerRenderer for the clock renderer.
-// Uses a 4-frame subset of braille icons for slower animation.
-func (r *clockRenderer) Render(s *Spinner, frame int, w io.Writer) {
-	icons := []ansi.Icon{
-		ansi.Spinner1, ansi.Spinner3, ansi.Spinner5, ansi.Spinner7,
-	}
+// Render implements SpinnerRenderer, drawing the frame for the animation step followed by the message.
+func (f *Frames) Render(s *Spinner, frame int, w io.Writer) {
+	f.RenderWithContext(s.renderContext(frame), w)
+}
 
-	icon := icons[frame%len(icons)]
-	color := s.CurrentColor(frame)
-	coloredIcon := icon.Colorize(color)
+// RenderWithContext draws the frame for the animation step in ctx followed by the message, so Frames can
+// be used inside custom RenderContextFunc renderers.
+func (f *Frames) RenderWithContext(ctx RenderContext, w io.Writer) {
+	if len(f.frames) == 0 {
+		fmt.Fprint(w, ctx.Message)
+		return
+	}
 
-	fmt.Fprintf(w, "%s %s", coloredIcon, s.message)
+	fmt.Fprintf(w, "%s %s", f.glyph(ctx.Frame, ctx.Color), ctx.Message)
 }
 
-// Render implements SpinnerRenderer for the arrow renderer.
-// Uses directional arrows rotating clockwise (→↓←↑) with color cycling.
-func (r *arrowRenderer) Render(s *Spinner, frame int, w io.Writer) {
-	icons := []ansi.Icon{
-		ansi.ArrowRight, ansi.ArrowDown, ansi.ArrowLeft, ansi.ArrowUp,
+// glyph returns the icon for an animation step in color, padded to the width of the widest frame
+func (f *Frames) glyph(frame int, color ansi.Color) string {
+	if len(f.frames) == 0 {
+		return ""
 	}
 
-	icon := icons[frame%len(icons)]
-	color := s.CurrentColor(frame)
-	coloredIcon := icon.Colorize(color)
+	icon := f.frames[frame%len(f.frames)]
+	return color.Colorize(icon) + strings.Repeat(" ", f.width-term.PrintableWidth(icon))
+}
+
+// Interval returns the time each frame is shown for.
+func (f *Frames) Interval() time.Duration {
+	return f.interval
+}
 
-	fmt.Fprintf(w, "%s %s", coloredIcon, s.message)
+// Len returns the number of frames in the animation.
+func (f *Frames) Len() int {
+	return len(f.frames)
 }
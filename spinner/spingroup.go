@@ -4,9 +4,9 @@ import (
 	"io"
 	"os"
 	"sync"
-	"time"
 
 	"github.com/pkg/errors"
+	"github.com/pseudomuto/gooey/clock"
 	"github.com/pseudomuto/gooey/frame"
 	"github.com/pseudomuto/gooey/internal/writer"
 )
@@ -21,9 +21,10 @@ type (
 		mutex         sync.RWMutex
 		output        io.Writer
 		running       bool
-		startTime     time.Time
-		currentIndex  int // Track the currently executing task for dynamic insertion
-		subtaskOffset int // Track how many subtasks have been added during current task execution
+		clock         clock.Clock // drives the task components and frame when set, see WithSpinGroupClock
+		currentIndex  int         // Track the currently executing task for dynamic insertion
+		subtaskOffset int         // Track how many subtasks have been added during current task execution
+		frameOptions  []frame.FrameOption
 	}
 
 	// SpinGroupTask represents a single task with its component and function
@@ -34,6 +35,11 @@ type (
 		depth     int // Track nesting depth for indentation (0 = root task, 1+ = subtask)
 	}
 
+	// clockSetter is implemented by task components whose clock can be replaced
+	clockSetter interface {
+		SetClock(c clock.Clock)
+	}
+
 	// SpinGroupOption is a function type for configuring spin groups
 	SpinGroupOption func(*SpinGroup)
 )
@@ -235,7 +241,6 @@ func (sg *SpinGroup) initializeExecution() {
 	defer sg.mutex.Unlock()
 
 	sg.running = true
-	sg.startTime = time.Now()
 }
 
 // finalizeExecution cleans up the execution state
@@ -269,6 +274,9 @@ func (sg *SpinGroup) executeTask(taskIndex int) error {
 	// Set component output with appropriate indentation based on task depth
 	taskOutput := writer.NewIndentedWriter(sg.output, task.depth)
 	task.component.SetOutput(taskOutput)
+	if c, ok := task.component.(clockSetter); ok && sg.clock != nil {
+		c.SetClock(sg.clock)
+	}
 
 	// Start the component (spinners animate, progress shows)
 	task.component.Start()
@@ -337,7 +345,12 @@ func (sg *SpinGroup) validate() error {
 
 // RunInFrame runs all tasks within a frame for organized display
 func (sg *SpinGroup) RunInFrame() error {
-	f := frame.Open(sg.title, frame.WithOutput(sg.output))
+	options := []frame.FrameOption{frame.WithOutput(sg.output)}
+	if sg.clock != nil {
+		options = append(options, frame.WithClock(sg.clock))
+	}
+
+	f := frame.Open(sg.title, append(options, sg.frameOptions...)...)
 	defer f.Close()
 
 	// Instead of setting the frame as the output (which causes nesting issues),
@@ -372,3 +385,34 @@ func WithSpinGroupOutput(output io.Writer) SpinGroupOption {
 		sg.output = output
 	}
 }
+
+// WithSpinGroupClock sets the clock that drives the spin group's task components and the frame opened by
+// RunInFrame, overriding the clocks the components were created with. Components are given the clock
+// when their task starts, if they have a SetClock method like Spinner and Progress.
+//
+// Example:
+//
+//	fake := clock.NewFake(time.Now())
+//	sg := spinner.NewSpinGroup("Deploy", spinner.WithSpinGroupClock(fake))
+func WithSpinGroupClock(c clock.Clock) SpinGroupOption {
+	return func(sg *SpinGroup) {
+		sg.clock = c
+	}
+}
+
+// WithSpinGroupFrameOptions sets options for the frame opened by RunInFrame, e.g. to render the results
+// as Markdown in a GitHub Actions step summary.
+//
+// Example:
+//
+//	sg := spinner.NewSpinGroup("Release",
+//		spinner.WithSpinGroupFrameOptions(frame.WithMarkdown(summary)))
+//
+//	// Writes: ## Release
+//	//         - [x] Building... (12s)
+//	//         - [x] Publishing... (3s)
+func WithSpinGroupFrameOptions(options ...frame.FrameOption) SpinGroupOption {
+	return func(sg *SpinGroup) {
+		sg.frameOptions = append(sg.frameOptions, options...)
+	}
+}
@@ -9,8 +9,13 @@ import (
 
 	"github.com/pkg/errors"
 	"github.com/pseudomuto/gooey/ansi"
+	"github.com/pseudomuto/gooey/ci"
+	"github.com/pseudomuto/gooey/clock"
+	"github.com/pseudomuto/gooey/frame"
 	"github.com/pseudomuto/gooey/progress"
 	"github.com/pseudomuto/gooey/spinner"
+	"github.com/pseudomuto/gooey/terminal"
+	"github.com/pseudomuto/gooey/termtest"
 	"github.com/stretchr/testify/require"
 )
 
@@ -165,6 +170,54 @@ func TestSpinGroup_RunInFrame(t *testing.T) {
 	require.Contains(t, output, "✓")          // Should show success indicator
 }
 
+func TestSpinGroup_RunInMarkdownFrame(t *testing.T) {
+	buf := &bytes.Buffer{}
+	sg := spinner.NewSpinGroup("Release", spinner.WithSpinGroupFrameOptions(
+		frame.WithMarkdown(buf),
+		frame.WithStack(frame.NewStack()),
+		frame.WithClock(clock.NewFake(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))),
+	))
+
+	c := clock.NewFake(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	sg.AddTask("Build", spinner.New("Building...", spinner.WithClock(c)), func(spinner.TaskComponent, *spinner.SpinGroup) error {
+		return nil
+	})
+	sg.AddTask("Publish", spinner.New("Publishing...", spinner.WithClock(c)), func(spinner.TaskComponent, *spinner.SpinGroup) error {
+		return nil
+	})
+
+	require.NoError(t, sg.RunInFrame())
+	require.Equal(t, "## Release\n- [x] Building... (0s)\n- [x] Publishing... (0s)\n\n", buf.String())
+}
+
+func TestSpinGroup_WithClock(t *testing.T) {
+	buf := &bytes.Buffer{}
+	c := clock.NewFake(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	env := terminal.Environment{Width: 40, TTY: terminal.TTYOff, Color: terminal.ColorNone, CI: ci.None}
+	sg := spinner.NewSpinGroup("Deploy",
+		spinner.WithSpinGroupOutput(buf),
+		spinner.WithSpinGroupClock(c),
+		spinner.WithSpinGroupFrameOptions(frame.WithStack(frame.NewStack()), frame.WithTerminal(env)))
+
+	// The group's clock replaces the clocks the components were created with
+	s := spinner.New("Building...", spinner.WithTerminal(env))
+	sg.AddTask("Build", s, func(spinner.TaskComponent, *spinner.SpinGroup) error {
+		c.Advance(2 * time.Second)
+		return nil
+	})
+
+	p := progress.New("Upload", 10, progress.WithTerminal(env))
+	sg.AddTask("Upload", p, func(spinner.TaskComponent, *spinner.SpinGroup) error {
+		c.Advance(time.Second)
+		require.Equal(t, time.Second, p.Elapsed())
+		return nil
+	})
+
+	require.NoError(t, sg.RunInFrame())
+	require.Contains(t, buf.String(), "✓ Building... (2s)")
+	require.Contains(t, buf.String(), "(3s) ┘")
+}
+
 func TestSpinGroup_WithCustomSpinners(t *testing.T) {
 	buf := &bytes.Buffer{}
 	sg := spinner.NewSpinGroup("Custom Spinners Test", spinner.WithSpinGroupOutput(buf))
@@ -505,3 +558,29 @@ func TestSpinGroup_SubtaskWithProgress(t *testing.T) {
 	require.Contains(t, output, "Download Files")
 	require.Contains(t, output, "Downloaded")
 }
+
+func TestSpinGroup_Snapshot(t *testing.T) {
+	fake := clock.NewFake(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	defer clock.SetDefault(fake)()
+	defer terminal.SetDefault(terminal.Environment{Width: 50, TTY: terminal.TTYOn, CI: ci.None})()
+
+	vt := termtest.New(50)
+	sg := spinner.NewSpinGroup("Pipeline", spinner.WithSpinGroupOutput(vt))
+
+	sg.AddTask("Build", spinner.New("Building..."), func(c spinner.TaskComponent, sg *spinner.SpinGroup) error {
+		fake.Advance(300 * time.Millisecond)
+		sg.AddSubtask("Lint", spinner.New("Linting..."), func(spinner.TaskComponent, *spinner.SpinGroup) error {
+			fake.Advance(100 * time.Millisecond)
+			return nil
+		})
+		return nil
+	})
+
+	sg.AddTask("Deploy", spinner.New("Deploying..."), func(spinner.TaskComponent, *spinner.SpinGroup) error {
+		fake.Advance(200 * time.Millisecond)
+		return errors.New("connection refused")
+	})
+
+	require.Error(t, sg.RunInFrame())
+	termtest.Golden(t, "spingroup", vt.Screen().String())
+}
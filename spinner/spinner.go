@@ -7,11 +7,14 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/pseudomuto/gooey/ansi"
+	"github.com/pseudomuto/gooey/clock"
 	"github.com/pseudomuto/gooey/internal/frame"
+	"github.com/pseudomuto/gooey/terminal"
 )
 
 const (
@@ -21,6 +24,8 @@ const (
 	SpinnerCompleted SpinnerState = iota
 	// SpinnerFailed indicates the spinner finished with an error
 	SpinnerFailed
+	// SpinnerInterrupted indicates the spinner was finished early because the process was interrupted
+	SpinnerInterrupted
 )
 
 var (
@@ -47,12 +52,16 @@ type (
 		suppressRender bool // prevents rendering when used in groups
 		frameAware     *frame.FrameAware
 		interval       time.Duration
+		customInterval bool // tracks if the interval was explicitly set via WithInterval
+		clock          clock.Clock
 		running        bool
 		state          SpinnerState
 		startTime      time.Time
 		stopChan       chan bool
 		mutex          sync.RWMutex
 		renderer       SpinnerRenderer
+		showCursor     func()
+		untrack        func()
 	}
 
 	// SpinnerOption is a function type for configuring spinners
@@ -100,6 +109,7 @@ func New(message string, options ...SpinnerOption) *Spinner {
 		showElapsed: true,             // Default is to show elapsed time
 		frameAware:  frame.NewFrameAware(defaultSpinnerOutput),
 		interval:    defaultSpinnerInterval,
+		clock:       clock.Default(),
 		running:     false,
 		state:       SpinnerCompleted, // Default to completed state
 		stopChan:    make(chan bool),
@@ -110,6 +120,11 @@ func New(message string, options ...SpinnerOption) *Spinner {
 		option(s)
 	}
 
+	// Renderers such as NewFrames carry the interval their animation is designed for
+	if r, ok := s.renderer.(intervalRenderer); ok && !s.customInterval && r.Interval() > 0 {
+		s.interval = r.Interval()
+	}
+
 	return s
 }
 
@@ -122,33 +137,25 @@ func (s *Spinner) Start() {
 	}
 
 	s.running = true
-	s.startTime = time.Now()
+	s.startTime = s.clock.Now()
+	s.showCursor = func() {}
+	if !s.suppressRender {
+		s.showCursor = s.frameAware.HideCursor()
+	}
+	s.untrack = terminal.Track(s)
 	s.mutex.Unlock()
 
 	// Immediately render the first frame to ensure visibility for fast-completing tasks
 	s.render(0)
 
-	go s.animate()
+	// Create the ticker before starting the goroutine so that no tick can be missed between
+	// Start returning and the animation loop being ready
+	go s.animate(s.clock.NewTicker(s.interval))
 }
 
 // Stop ends the spinner animation and renders the final state with success
 func (s *Spinner) Stop() {
-	s.mutex.Lock()
-	if !s.running {
-		s.mutex.Unlock()
-		return
-	}
-
-	s.running = false
-	s.state = SpinnerCompleted
-	s.mutex.Unlock()
-
-	s.stopChan <- true
-	s.renderFinal()
-
-	if !s.frameAware.InFrame() {
-		fmt.Fprintln(s.frameAware.Output())
-	}
+	s.finish(SpinnerCompleted)
 }
 
 // Fail ends the spinner animation and renders the final state with failure.
@@ -165,6 +172,23 @@ func (s *Spinner) Fail(message string) {
 	if message != "" {
 		s.UpdateMessage(message)
 	}
+	s.finish(SpinnerFailed)
+}
+
+// Interrupt ends the spinner animation and renders the final state as interrupted. It is called by the
+// terminal cleanup manager when the process is interrupted (see terminal.HandleSignals) or panics.
+//
+// Example:
+//
+//	s := spinner.New("Deploying...")
+//	s.Start()
+//	s.Interrupt() // Shows: ⚠ Deploying... (interrupted)
+func (s *Spinner) Interrupt() {
+	s.finish(SpinnerInterrupted)
+}
+
+// finish stops the animation, renders the final state and restores the cursor
+func (s *Spinner) finish(state SpinnerState) {
 	s.mutex.Lock()
 	if !s.running {
 		s.mutex.Unlock()
@@ -172,7 +196,7 @@ func (s *Spinner) Fail(message string) {
 	}
 
 	s.running = false
-	s.state = SpinnerFailed
+	s.state = state
 	s.mutex.Unlock()
 
 	s.stopChan <- true
@@ -181,6 +205,9 @@ func (s *Spinner) Fail(message string) {
 	if !s.frameAware.InFrame() {
 		fmt.Fprintln(s.frameAware.Output())
 	}
+
+	s.untrack()
+	s.showCursor()
 }
 
 // UpdateMessage changes the spinner message while it's running
@@ -206,6 +233,21 @@ func (s *Spinner) Complete(message string) {
 	s.Stop()
 }
 
+// SetClock replaces the clock used for the animation ticker and elapsed time. It has no effect on a
+// spinner that's already running.
+//
+// Example:
+//
+//	s := spinner.New("Loading...")
+//	s.SetClock(clock.NewFake(time.Now()))
+func (s *Spinner) SetClock(c clock.Clock) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if c != nil && !s.running {
+		s.clock = c
+	}
+}
+
 // SetOutput sets the output writer for the spinner, allowing redirection
 // for frame integration or custom output destinations.
 //
@@ -218,8 +260,7 @@ func (s *Spinner) SetOutput(output io.Writer) {
 	s.frameAware.SetOutput(output)
 }
 
-func (s *Spinner) animate() {
-	ticker := time.NewTicker(s.interval)
+func (s *Spinner) animate(ticker clock.Ticker) {
 	defer ticker.Stop()
 
 	frame := 0
@@ -227,17 +268,20 @@ func (s *Spinner) animate() {
 		select {
 		case <-s.stopChan:
 			return
-		case <-ticker.C:
+		case <-ticker.C():
 			s.render(frame)
 			frame++
 		}
 	}
 }
 
+// render draws a single animation frame. It is only called from Start and the animation loop, and
+// Stop/Fail wait for the loop to exit before rendering the final state, so a frame that has been
+// ticked is always rendered in full before the final state.
 func (s *Spinner) render(frame int) {
 	s.mutex.RLock()
 	defer s.mutex.RUnlock()
-	if !s.running || s.suppressRender {
+	if s.suppressRender {
 		return
 	}
 
@@ -252,18 +296,22 @@ func (s *Spinner) renderFinal() {
 	}
 
 	var icon string
-	if s.state == SpinnerFailed {
+	switch s.state {
+	case SpinnerFailed:
 		icon = ansi.CrossMark.Colorize(ansi.Red)
-	} else {
+	case SpinnerInterrupted:
+		icon = ansi.Warning.Colorize(ansi.Yellow)
+	case SpinnerCompleted:
 		icon = ansi.CheckMark.Colorize(ansi.Green)
 	}
 
 	message := s.message
 
 	var elapsedText string
-	if s.showElapsed {
-		elapsed := time.Since(s.startTime)
-		elapsedText = " " + ansi.Cyan.Colorize(fmt.Sprintf("(%v)", elapsed.Truncate(time.Millisecond)))
+	if s.state == SpinnerInterrupted {
+		elapsedText = " " + ansi.Yellow.Colorize("(interrupted)")
+	} else if s.showElapsed {
+		elapsedText = " " + ansi.Cyan.Colorize("("+formatElapsed(s.clock.Since(s.startTime))+")")
 	}
 
 	s.frameAware.RenderFinal(func() string {
@@ -279,15 +327,48 @@ func WithColor(color ansi.Color) SpinnerOption {
 	}
 }
 
-// WithInterval sets the animation interval for the spinner
+// WithInterval sets the animation interval for the spinner, overriding the renderer's own interval
 func WithInterval(interval time.Duration) SpinnerOption {
 	return func(s *Spinner) {
 		if interval > 0 {
 			s.interval = interval
+			s.customInterval = true
 		}
 	}
 }
 
+// WithClock sets the clock used for the animation ticker and elapsed time.
+// By default, spinners use clock.Default(), which is the system clock unless overridden.
+//
+// Example:
+//
+//	fake := clock.NewFake(time.Now())
+//	s := spinner.New("Loading...", spinner.WithClock(fake))
+//	s.Start()
+//	fake.Advance(300 * time.Millisecond) // renders three animation frames
+//	s.Stop()                             // Shows: ✓ Loading... (300ms)
+func WithClock(c clock.Clock) SpinnerOption {
+	return func(s *Spinner) {
+		if c != nil {
+			s.clock = c
+		}
+	}
+}
+
+// WithTerminal overrides the terminal environment the spinner renders into.
+// By default, spinners use terminal.Default(), which detects the width, TTY status and colour
+// support from the running process unless overridden.
+//
+// Example:
+//
+//	// Plain output without colour codes
+//	s := spinner.New("Loading...", spinner.WithTerminal(terminal.Environment{Color: terminal.ColorNone}))
+func WithTerminal(env terminal.Environment) SpinnerOption {
+	return func(s *Spinner) {
+		s.frameAware.SetEnvironment(env)
+	}
+}
+
 // WithOutput sets the output writer for the spinner
 func WithOutput(output io.Writer) SpinnerOption {
 	return func(s *Spinner) {
@@ -355,6 +436,60 @@ func (s *Spinner) ShowElapsed() bool {
 	return s.showElapsed
 }
 
+// Interval returns the time between animation frames
+func (s *Spinner) Interval() time.Duration {
+	return s.interval
+}
+
+// Glyph returns the spinner's icon for an animation frame, drawn by its renderer without the message.
+// This lets frames animate the spinner in their title. Frames renderers such as Dots and
+// RenderContextFunc renderers draw the icon directly. Other renderers only draw from a spinner, so
+// they're given a stand-in with no message and this spinner's colour for the frame; anything else they
+// read from the spinner (e.g. its elapsed time) is the stand-in's.
+//
+// Example:
+//
+//	s := spinner.New("", spinner.WithRenderer(spinner.Arrow), spinner.WithColor(ansi.Blue))
+//	s.Glyph(1) // Returns: "↓" in blue
+//
+//	f := frame.Open("Build", frame.WithSpinner(s))
+func (s *Spinner) Glyph(frame int) string {
+	color := s.CurrentColor(frame)
+
+	var buf strings.Builder
+	switch r := s.renderer.(type) {
+	case *Frames:
+		buf.WriteString(r.glyph(frame, color))
+	case RenderContextFunc:
+		r(RenderContext{Frame: frame, Color: color}, &buf)
+	default:
+		// Other renderers only draw from a spinner, so give them a stand-in without a message, which
+		// leaves the icon
+		s.renderer.Render(New("", WithColor(color)), frame, &buf)
+	}
+
+	// Only the trailing space is trimmed, so frames that start with a space keep their position
+	return strings.TrimRight(buf.String(), " ")
+}
+
+// renderContext snapshots the spinner's state for rendering an animation frame. It reads the fields
+// directly, since renderers run while render holds the spinner's read lock.
+func (s *Spinner) renderContext(frame int) RenderContext {
+	ctx := RenderContext{Message: s.message, Frame: frame, Color: s.CurrentColor(frame)}
+	if s.running {
+		ctx.Elapsed = s.clock.Since(s.startTime)
+	}
+	ctx.Width = s.frameAware.Width()
+	ctx.InFrame = s.frameAware.InFrame()
+
+	return ctx
+}
+
+// formatElapsed formats an elapsed time for display, truncated to milliseconds
+func formatElapsed(elapsed time.Duration) string {
+	return elapsed.Truncate(time.Millisecond).String()
+}
+
 // CurrentColor returns the color for the current frame, rotating through spinnerColors
 func (s *Spinner) CurrentColor(frame int) ansi.Color {
 	if s.customColor {
@@ -372,7 +507,7 @@ func (s *Spinner) Elapsed() time.Duration {
 	if !s.running {
 		return 0
 	}
-	return time.Since(s.startTime)
+	return s.clock.Since(s.startTime)
 }
 
 // State returns the current completion state of the spinner
@@ -4,15 +4,29 @@ import (
 	"bytes"
 	"fmt"
 	"io"
+	"os"
+	"regexp"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/mattn/go-runewidth"
 	"github.com/pseudomuto/gooey/ansi"
+	"github.com/pseudomuto/gooey/ci"
+	"github.com/pseudomuto/gooey/clock"
+	"github.com/pseudomuto/gooey/frame"
 	. "github.com/pseudomuto/gooey/spinner"
+	"github.com/pseudomuto/gooey/terminal"
+	"github.com/pseudomuto/gooey/termtest"
 	"github.com/stretchr/testify/require"
 )
 
+// TestMain renders as on a local terminal, even when the tests run in CI
+func TestMain(m *testing.M) {
+	terminal.SetDefault(terminal.Environment{CI: ci.None})
+	os.Exit(m.Run())
+}
+
 func TestNew(t *testing.T) {
 	s := New("test message")
 
@@ -136,6 +150,50 @@ func TestRenderers(t *testing.T) {
 	}
 }
 
+func TestNewFrames(t *testing.T) {
+	pong := NewFrames(80*time.Millisecond, "▐⠂  ▌", "▐ ⠂ ▌", "▐  ⠂▌")
+	require.Equal(t, 3, pong.Len())
+
+	// The renderer's interval is used unless one is given explicitly
+	require.Equal(t, 80*time.Millisecond, New("Waiting...", WithRenderer(pong)).Interval())
+	require.Equal(t, time.Second, New("Waiting...", WithInterval(time.Second), WithRenderer(pong)).Interval())
+	require.Equal(t, 100*time.Millisecond, New("Waiting...", WithRenderer(NewFrames(0, "x"))).Interval())
+
+	s := New("Waiting...", WithColor(ansi.Blue))
+	var buf strings.Builder
+	pong.Render(s, 4, &buf)
+	require.Equal(t, ansi.Blue.Colorize("▐ ⠂ ▌")+" Waiting...", buf.String())
+}
+
+func TestCatalogueKeepsTheMessageColumnSteady(t *testing.T) {
+	renderers := map[string]*Frames{
+		"Dots": Dots, "Clock": Clock, "Arrow": Arrow, "Line": Line, "Bounce": Bounce, "Pulse": Pulse,
+		"Points": Points, "Arc": Arc, "GrowingBar": GrowingBar, "BrailleCircle": BrailleCircle,
+		"Triangle": Triangle, "SquareCorners": SquareCorners, "Star": Star, "Toggle": Toggle,
+		"BouncingBall": BouncingBall, "Moon": Moon, "Earth": Earth, "Hourglass": Hourglass,
+	}
+
+	s := New("msg")
+	for name, renderer := range renderers {
+		t.Run(name, func(t *testing.T) {
+			require.Positive(t, renderer.Interval())
+
+			column := -1
+			for frame := range renderer.Len() {
+				var buf strings.Builder
+				renderer.Render(s, frame, &buf)
+
+				prefix, _, found := strings.Cut(ansi.StripStyles(buf.String()), "msg")
+				require.True(t, found)
+				if column < 0 {
+					column = runewidth.StringWidth(prefix)
+				}
+				require.Equal(t, column, runewidth.StringWidth(prefix), "frame %d", frame)
+			}
+		})
+	}
+}
+
 func TestCustomRenderer(t *testing.T) {
 	var buf bytes.Buffer
 
@@ -156,6 +214,39 @@ func TestCustomRenderer(t *testing.T) {
 	require.Contains(t, output, "CUSTOM: test")
 }
 
+func TestRenderContextFunc(t *testing.T) {
+	fake := clock.NewFake(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	env := terminal.Environment{Width: 40, TTY: terminal.TTYOff, Color: terminal.ColorNone, CI: ci.None}
+
+	var buf bytes.Buffer
+	f := frame.Open("Deploy", frame.WithOutput(&buf), frame.WithStack(frame.NewStack()), frame.WithTerminal(env))
+
+	// A right-aligned timer, which needs the width budget and elapsed time
+	contexts := make(chan RenderContext, 10)
+	timer := RenderContextFunc(func(ctx RenderContext, w io.Writer) {
+		contexts <- ctx
+		elapsed := ctx.ElapsedText()
+		fmt.Fprintf(w, "%s%s%s", ctx.Message, strings.Repeat(" ", ctx.Width-len(ctx.Message)-len(elapsed)), elapsed)
+	})
+
+	s := New("Uploading", WithOutput(f), WithClock(fake), WithTerminal(env), WithRenderer(timer), WithColor(ansi.Blue))
+	s.Start()
+	fake.Advance(100 * time.Millisecond)
+	s.Stop()
+	f.Close()
+
+	first, second := <-contexts, <-contexts
+	require.Equal(t, RenderContext{Message: "Uploading", Frame: 0, Color: ansi.Blue, Width: 37, InFrame: true}, first)
+	require.Equal(t, 100*time.Millisecond, second.Elapsed)
+	require.Contains(t, buf.String(), "│ Uploading                       100ms│")
+
+	// Standalone spinners get the terminal width
+	standalone := New("Uploading", WithOutput(io.Discard), WithTerminal(env), WithRenderer(timer))
+	standalone.Start()
+	standalone.Stop()
+	require.Equal(t, 40, (<-contexts).Width)
+}
+
 func TestSpinnerAnimation(t *testing.T) {
 	var buf bytes.Buffer
 	s := New("animating",
@@ -326,3 +417,176 @@ func TestFailureWithoutElapsedTime(t *testing.T) {
 	require.Contains(t, output, ansi.CrossMark.String())
 	require.NotContains(t, output, "(") // Should not contain elapsed time
 }
+
+func TestSpinnerWithFakeClock(t *testing.T) {
+	run := func() string {
+		fake := clock.NewFake(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+		vt := termtest.New(40)
+		s := New("Loading...", WithClock(fake), WithOutput(vt), WithColor(ansi.Blue),
+			WithTerminal(terminal.Environment{Width: 40, TTY: terminal.TTYOn, CI: ci.None}))
+
+		s.Start()
+		fake.Advance(250 * time.Millisecond)
+		s.Stop()
+
+		require.Equal(t, "✓ Loading... (250ms)\n", vt.Screen().String())
+		return vt.Raw()
+	}
+
+	first := run()
+	require.Equal(t, first, run(), "output should be byte-for-byte reproducible")
+
+	// Start renders frame 0 immediately, then each of the two ticks renders the next frame
+	require.Equal(t, 2, strings.Count(first, ansi.Spinner1.String()))
+	require.Equal(t, 1, strings.Count(first, ansi.Spinner2.String()))
+	require.Zero(t, strings.Count(first, ansi.Spinner3.String()))
+}
+
+func TestSpinnerWithoutTTY(t *testing.T) {
+	fake := clock.NewFake(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	env := terminal.Environment{Width: 40, TTY: terminal.TTYOff, Color: terminal.ColorNone, CI: ci.None}
+
+	var buf bytes.Buffer
+	s := New("Load", WithClock(fake), WithOutput(&buf), WithTerminal(env))
+	s.Start()
+	fake.Advance(200 * time.Millisecond)
+	s.Stop()
+
+	// Each update is appended as a new line rather than redrawn in place
+	require.Equal(t, strings.Join([]string{
+		ansi.Spinner1.String() + " Load",
+		ansi.Spinner1.String() + " Load",
+		ansi.Spinner2.String() + " Load",
+		"✓ Load (200ms)",
+		"",
+	}, "\n"), buf.String())
+}
+
+func TestSpinnerWithoutColor(t *testing.T) {
+	var buf bytes.Buffer
+	s := New("plain", WithOutput(&buf), WithTerminal(terminal.Environment{Color: terminal.ColorNone, CI: ci.None}))
+
+	s.Start()
+	s.Stop()
+
+	require.NotContains(t, buf.String(), "\033[3")
+	require.Contains(t, buf.String(), ansi.CheckMark.String()+" plain")
+}
+
+func TestSpinnerHidesCursorWhileRunning(t *testing.T) {
+	vt := termtest.New(40)
+	s := New("Loading...", WithOutput(vt), WithTerminal(terminal.Environment{TTY: terminal.TTYOn, CI: ci.None}))
+
+	s.Start()
+	require.False(t, vt.CursorVisible())
+
+	s.Stop()
+	require.True(t, vt.CursorVisible())
+}
+
+func TestSpinnerInterrupt(t *testing.T) {
+	fake := clock.NewFake(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	vt := termtest.New(60)
+	env := terminal.Environment{Width: 60, TTY: terminal.TTYOn, CI: ci.None}
+
+	f := frame.Open("Deploy", frame.WithOutput(vt), frame.WithClock(fake), frame.WithTerminal(env))
+	s := New("Uploading...", WithOutput(f), WithClock(fake), WithTerminal(env))
+	s.Start()
+	fake.Advance(1500 * time.Millisecond)
+
+	terminal.Interrupt()
+
+	require.Equal(t, SpinnerInterrupted, s.State())
+	require.False(t, s.IsRunning())
+	require.True(t, vt.CursorVisible())
+	require.Equal(t, strings.Join([]string{
+		"┌── Deploy ────────────────────────────────────────────────┐",
+		"│ ⚠ Uploading... (interrupted)                             │",
+		"└──────────────────────────────────── ✗ interrupted (1.5s) ┘",
+		"",
+	}, "\n"), vt.Screen().String())
+
+	// Finishing after an interrupt does nothing
+	raw := vt.Raw()
+	s.Stop()
+	f.Close()
+	require.Equal(t, raw, vt.Raw())
+}
+
+func TestSpinnerInCILog(t *testing.T) {
+	fake := clock.NewFake(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	env := terminal.Environment{Width: 40, TTY: terminal.TTYOff, Color: terminal.ColorNone, CI: ci.GitLab}
+
+	var buf bytes.Buffer
+	s := New("Uploading...", WithOutput(&buf), WithClock(fake), WithTerminal(env), WithRenderer(Dots))
+	s.Start()
+	fake.Advance(250 * time.Millisecond)
+	s.Stop()
+
+	// The animation isn't redrawn, just the start and finish lines
+	require.Equal(t, ansi.Spinner1.String()+" Uploading...\n"+ansi.CheckMark.String()+" Uploading... (250ms)\n", buf.String())
+
+	buf.Reset()
+	f := frame.Open("Deploy", frame.WithOutput(&buf), frame.WithStack(frame.NewStack()), frame.WithClock(fake),
+		frame.WithTerminal(env))
+	s = New("Migrating...", WithOutput(f), WithClock(fake), WithTerminal(env), WithRenderer(Dots))
+	s.Start()
+	fake.Advance(250 * time.Millisecond)
+	s.Fail("")
+	f.Close()
+
+	// Frames are folded into collapsible GitLab sections, whose IDs are numbered across the process
+	ids := regexp.MustCompile(`gooey_\d+_deploy`).FindAllString(buf.String(), -1)
+	require.Len(t, ids, 2)
+	require.Equal(t, ids[0], ids[1])
+	require.Equal(t, strings.Join([]string{
+		"\x1b[Ksection_start:1704067200:" + ids[0] + "[collapsed=true]\r\x1b[KDeploy",
+		"┌── Deploy ────────────────────────────┐",
+		"│ ⠋ Migrating...                       │",
+		"│ ✗ Migrating... (250ms)               │",
+		"└───────────────────────────── (250ms) ┘",
+		"\x1b[Ksection_end:1704067200:" + ids[0] + "\r\x1b[K",
+		"",
+	}, "\n"), buf.String())
+}
+
+func TestGlyph(t *testing.T) {
+	s := New("Loading...", WithRenderer(Arrow), WithColor(ansi.Blue), WithInterval(50*time.Millisecond))
+
+	require.Equal(t, ansi.ArrowDown.Colorize(ansi.Blue), s.Glyph(1))
+	require.Equal(t, 50*time.Millisecond, s.Interval())
+
+	// Without a fixed colour, glyphs rotate through the spinner colours
+	require.Equal(t, ansi.Spinner2.Colorize(ansi.Blue), New("Loading...").Glyph(1))
+
+	// Custom renderers draw without the message
+	dot := RenderContextFunc(func(ctx RenderContext, w io.Writer) {
+		fmt.Fprintf(w, "%s %s", ctx.Color.Colorize("●"), ctx.Message)
+	})
+	require.Equal(t, ansi.Green.Colorize("●"), New("Loading...", WithRenderer(dot), WithColor(ansi.Green)).Glyph(3))
+
+	star := RenderFunc(func(s *Spinner, frame int, w io.Writer) {
+		fmt.Fprintf(w, "%s ", s.CurrentColor(frame).Colorize("*"))
+	})
+	require.Equal(t, ansi.Blue.Colorize("*"), New("Loading...", WithRenderer(star)).Glyph(1))
+
+	// Other renderers draw from a stand-in spinner without the message
+	labelled := RenderFunc(func(s *Spinner, frame int, w io.Writer) {
+		fmt.Fprintf(w, "%s %s", s.CurrentColor(frame).Colorize("*"), s.Message())
+	})
+	require.Equal(t, ansi.Green.Colorize("*"), New("Loading...", WithRenderer(labelled), WithColor(ansi.Green)).Glyph(2))
+}
+
+func TestSpinnerInFrameTitle(t *testing.T) {
+	vt := termtest.New(30)
+	f := frame.Open("Build",
+		frame.WithOutput(vt),
+		frame.WithStack(frame.NewStack()),
+		frame.WithClock(clock.NewFake(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))),
+		frame.WithTerminal(terminal.Environment{Width: 30, Height: 24, TTY: terminal.TTYOn, Color: terminal.ColorNone, CI: ci.None}),
+		frame.WithSpinner(New("", WithRenderer(Arrow))))
+
+	require.Equal(t, "┌── → Build ─────────────────┐", vt.Screen().Line(0))
+	f.Close()
+	require.Equal(t, "┌── ✓ Build ─────────────────┐", vt.Screen().Line(0))
+}
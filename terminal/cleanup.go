@@ -0,0 +1,203 @@
+package terminal
+
+import (
+	"io"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+
+	"github.com/pseudomuto/gooey/ansi"
+)
+
+var cleanup = new(cleanupManager)
+
+type (
+	// Interrupter is implemented by components that can be finished early when the process is
+	// interrupted, such as running spinners, progress bars and open frames.
+	Interrupter interface {
+		// Interrupt finishes the component immediately, leaving the terminal in a readable state.
+		Interrupt()
+	}
+
+	// InterruptFunc adapts an ordinary function to the Interrupter interface.
+	InterruptFunc func()
+
+	cleanupManager struct {
+		mutex   sync.Mutex
+		nextID  int
+		entries []cleanupEntry
+	}
+
+	cleanupEntry struct {
+		id          int
+		interrupter Interrupter
+	}
+)
+
+// Interrupt calls f.
+func (f InterruptFunc) Interrupt() {
+	f()
+}
+
+// Track registers c to be interrupted when Interrupt is called, when a panic reaches Recover, or, once
+// HandleSignals has been called, when the process receives SIGINT or SIGTERM. The returned function
+// removes the registration and is safe to call more than once. Components are interrupted in the reverse
+// order they were tracked, so a spinner started inside a frame is finished before the frame is closed.
+//
+// Example:
+//
+//	untrack := terminal.Track(terminal.InterruptFunc(func() {
+//		fmt.Fprintln(os.Stderr, "upload interrupted")
+//	}))
+//	defer untrack()
+func Track(c Interrupter) (untrack func()) {
+	id := cleanup.add(c)
+
+	var once sync.Once
+	return func() {
+		once.Do(func() { cleanup.remove(id) })
+	}
+}
+
+// HideCursor hides the cursor on w and tracks its restoration. The returned function shows the cursor
+// again and is safe to call more than once. If the process is interrupted first, the cursor is restored
+// automatically.
+//
+// Example:
+//
+//	show := terminal.HideCursor(os.Stdout)
+//	defer show()
+func HideCursor(w io.Writer) (show func()) {
+	_, _ = io.WriteString(w, ansi.HideCursor)
+
+	var once sync.Once
+	var untrack func()
+	show = func() {
+		once.Do(func() {
+			untrack()
+			_, _ = io.WriteString(w, ansi.ShowCursor)
+		})
+	}
+
+	untrack = Track(InterruptFunc(show))
+	return show
+}
+
+// HandleSignals interrupts every tracked component when the process receives SIGINT or SIGTERM, then
+// re-delivers the signal so the process terminates as it would have otherwise. Signals are only
+// intercepted after HandleSignals is called; applications that handle signals themselves should call
+// Interrupt from their own handler instead. The returned function stops handling signals and is safe
+// to call more than once.
+//
+// Example:
+//
+//	func main() {
+//		defer terminal.HandleSignals()()
+//
+//		f := frame.Open("Deploy")
+//		defer f.Close()
+//		deploy() // Ctrl-C closes the frame with ✗ interrupted and restores the cursor before exiting
+//	}
+func HandleSignals() (stop func()) {
+	signals := make(chan os.Signal, 1)
+	done := make(chan struct{})
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+
+	go func() {
+		select {
+		case sig := <-signals:
+			signal.Stop(signals)
+			Interrupt()
+			raise(sig)
+		case <-done:
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			signal.Stop(signals)
+			close(done)
+		})
+	}
+}
+
+// Interrupt interrupts every tracked component, newest first. Components are untracked as they are
+// interrupted, so calling Interrupt again only affects components tracked since.
+func Interrupt() {
+	for _, c := range cleanup.drain() {
+		c.Interrupt()
+	}
+}
+
+// Recover interrupts every tracked component when the calling goroutine is panicking, then re-panics
+// with the original value. It must be called directly by defer.
+//
+// Example:
+//
+//	func main() {
+//		defer terminal.Recover()
+//
+//		f := frame.Open("Deploy")
+//		defer f.Close()
+//		deploy() // a panic here closes the frame and restores the cursor before the stack trace
+//	}
+func Recover() {
+	if r := recover(); r != nil {
+		Interrupt()
+		panic(r)
+	}
+}
+
+func (m *cleanupManager) add(c Interrupter) int {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.nextID++
+	m.entries = append(m.entries, cleanupEntry{id: m.nextID, interrupter: c})
+	return m.nextID
+}
+
+func (m *cleanupManager) remove(id int) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	for i, entry := range m.entries {
+		if entry.id == id {
+			m.entries = append(m.entries[:i], m.entries[i+1:]...)
+			break
+		}
+	}
+}
+
+// drain removes every entry and returns the interrupters newest first
+func (m *cleanupManager) drain() []Interrupter {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	interrupters := make([]Interrupter, 0, len(m.entries))
+	for i := len(m.entries) - 1; i >= 0; i-- {
+		interrupters = append(interrupters, m.entries[i].interrupter)
+	}
+
+	m.entries = nil
+	return interrupters
+}
+
+// raise re-delivers sig to the current process now that its handler is no longer installed, so the
+// process terminates the same way it would have without gooey. If that isn't possible, the process
+// exits with the conventional 128+signal status.
+func raise(sig os.Signal) {
+	if p, err := os.FindProcess(os.Getpid()); err == nil {
+		if err := p.Signal(sig); err == nil {
+			return
+		}
+	}
+
+	code := 1
+	if s, ok := sig.(syscall.Signal); ok {
+		code = 128 + int(s)
+	}
+	os.Exit(code)
+}
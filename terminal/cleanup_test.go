@@ -0,0 +1,133 @@
+package terminal_test
+
+import (
+	"bytes"
+	"os"
+	"os/signal"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/pseudomuto/gooey/ansi"
+	. "github.com/pseudomuto/gooey/terminal"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrackInterruptsNewestFirst(t *testing.T) {
+	var order []string
+	track := func(name string) func() {
+		return Track(InterruptFunc(func() { order = append(order, name) }))
+	}
+
+	track("frame")
+	track("spinner")
+	untrack := track("cancelled")
+	untrack()
+	untrack() // safe to call twice
+
+	Interrupt()
+	require.Equal(t, []string{"spinner", "frame"}, order)
+
+	// Interrupted components are no longer tracked
+	Interrupt()
+	require.Equal(t, []string{"spinner", "frame"}, order)
+}
+
+func TestHideCursor(t *testing.T) {
+	var buf bytes.Buffer
+	show := HideCursor(&buf)
+	require.Equal(t, ansi.HideCursor, buf.String())
+
+	show()
+	show()
+	require.Equal(t, ansi.HideCursor+ansi.ShowCursor, buf.String())
+
+	// Shown cursors aren't restored again on interrupt
+	Interrupt()
+	require.Equal(t, ansi.HideCursor+ansi.ShowCursor, buf.String())
+}
+
+func TestInterruptRestoresHiddenCursor(t *testing.T) {
+	var buf bytes.Buffer
+	HideCursor(&buf)
+
+	Interrupt()
+	require.Equal(t, ansi.HideCursor+ansi.ShowCursor, buf.String())
+}
+
+func TestRecover(t *testing.T) {
+	var interrupted bool
+	untrack := Track(InterruptFunc(func() { interrupted = true }))
+	defer untrack()
+
+	require.PanicsWithValue(t, "boom", func() {
+		defer Recover()
+		panic("boom")
+	})
+	require.True(t, interrupted)
+}
+
+func TestRecoverWithoutPanic(t *testing.T) {
+	var interrupted bool
+	untrack := Track(InterruptFunc(func() { interrupted = true }))
+	defer untrack()
+
+	func() {
+		defer Recover()
+	}()
+	require.False(t, interrupted)
+}
+
+// signalSelf sends sig to the test process
+func signalSelf(t *testing.T, sig os.Signal) {
+	t.Helper()
+
+	p, err := os.FindProcess(os.Getpid())
+	require.NoError(t, err)
+	require.NoError(t, p.Signal(sig))
+}
+
+func TestTrackLeavesSignalsToTheApplication(t *testing.T) {
+	signals := make(chan os.Signal, 2)
+	signal.Notify(signals, syscall.SIGTERM)
+	defer signal.Stop(signals)
+
+	var interrupted atomic.Bool
+	untrack := Track(InterruptFunc(func() { interrupted.Store(true) }))
+	defer untrack()
+
+	signalSelf(t, syscall.SIGTERM)
+	require.Equal(t, syscall.SIGTERM, <-signals)
+
+	select {
+	case sig := <-signals:
+		require.Failf(t, "signal delivered twice", "received %v again", sig)
+	case <-time.After(100 * time.Millisecond):
+	}
+	require.False(t, interrupted.Load())
+}
+
+func TestHandleSignals(t *testing.T) {
+	// Stops the re-delivered signal from terminating the test
+	signals := make(chan os.Signal, 2)
+	signal.Notify(signals, syscall.SIGTERM)
+	defer signal.Stop(signals)
+
+	stop := HandleSignals()
+	defer stop()
+
+	interrupted := make(chan struct{})
+	untrack := Track(InterruptFunc(func() { close(interrupted) }))
+	defer untrack()
+
+	signalSelf(t, syscall.SIGTERM)
+	select {
+	case <-interrupted:
+	case <-time.After(time.Second):
+		require.Fail(t, "tracked component wasn't interrupted")
+	}
+
+	stop()
+	stop() // safe to call twice
+}
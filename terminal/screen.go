@@ -0,0 +1,183 @@
+package terminal
+
+import (
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/pseudomuto/gooey/ansi"
+)
+
+var defaultScreenOutput io.Writer = os.Stdout
+
+type (
+	// Screen is a fullscreen session on the terminal's alternate screen buffer. While a screen is active
+	// the cursor is hidden and each Render call redraws the whole screen from the top-left corner. When
+	// the session ends, the original screen contents and cursor are restored.
+	Screen struct {
+		output  io.Writer
+		env     Environment
+		mutex   sync.Mutex
+		active  bool
+		untrack func()
+	}
+
+	// ScreenOption is a function type for configuring screens
+	ScreenOption func(*Screen)
+)
+
+// NewScreen creates a fullscreen session. The session is not started until Enter or Run is called.
+//
+// Example:
+//
+//	scr := terminal.NewScreen()
+//	err := scr.Run(func(scr *terminal.Screen) error {
+//		for !done() {
+//			scr.Render(func(w io.Writer) {
+//				f := frame.Open("Status Board", frame.WithOutput(w))
+//				for _, svc := range services {
+//					f.Println("%s: %s", svc.Name, svc.Status)
+//				}
+//				f.Close()
+//			})
+//			time.Sleep(time.Second)
+//		}
+//		return nil
+//	})
+func NewScreen(options ...ScreenOption) *Screen {
+	s := &Screen{
+		output: defaultScreenOutput,
+		env:    Default(),
+	}
+
+	for _, option := range options {
+		option(s)
+	}
+
+	return s
+}
+
+// WithOutput sets the writer the screen renders to. By default, screens write to os.Stdout.
+func WithOutput(output io.Writer) ScreenOption {
+	return func(s *Screen) {
+		s.output = output
+	}
+}
+
+// WithEnvironment overrides the terminal environment used to size the screen and apply the
+// colour profile. By default, screens use Default().
+func WithEnvironment(env Environment) ScreenOption {
+	return func(s *Screen) {
+		s.env = env
+	}
+}
+
+// Enter switches to the alternate screen buffer, hides the cursor and clears the screen. Until Exit
+// is called, the screen is tracked for cleanup so that Interrupt, Recover and the signals handled by
+// HandleSignals restore the terminal before the process terminates. Calling Enter on an active screen
+// does nothing.
+func (s *Screen) Enter() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.active {
+		return
+	}
+
+	s.active = true
+	s.write(ansi.EnterAltScreen + ansi.HideCursor + ansi.ClearScreenAndHome())
+	s.untrack = Track(s)
+}
+
+// Exit restores the original screen contents and shows the cursor again. It is safe to call Exit
+// more than once, and on a screen that was never entered.
+func (s *Screen) Exit() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.exit()
+}
+
+// Interrupt exits the screen. It is called by the cleanup manager when the process is interrupted.
+func (s *Screen) Interrupt() {
+	s.Exit()
+}
+
+// Run enters the screen, calls fn and exits the screen when fn returns. If fn panics, the terminal is
+// restored before the panic propagates so that the stack trace is printed to the original screen.
+func (s *Screen) Run(fn func(*Screen) error) error {
+	s.Enter()
+	defer s.Exit()
+
+	return fn(s)
+}
+
+// Active reports whether the screen session is currently active.
+func (s *Screen) Active() bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.active
+}
+
+// Width returns the screen width in columns.
+func (s *Screen) Width() int {
+	return s.env.Columns()
+}
+
+// Height returns the screen height in rows.
+func (s *Screen) Height() int {
+	return s.env.Rows()
+}
+
+// Render redraws the screen with the output of fn. The content is drawn from the top-left corner,
+// clipped to the screen height, and anything left over from the previous render is cleared. Render
+// does nothing when the screen is not active.
+//
+// Example:
+//
+//	scr.Render(func(w io.Writer) {
+//		fmt.Fprintf(w, "Updated at %s\n", time.Now().Format(time.Kitchen))
+//	})
+func (s *Screen) Render(fn func(w io.Writer)) {
+	var content strings.Builder
+	fn(&content)
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if !s.active {
+		return
+	}
+
+	lines := strings.Split(strings.TrimSuffix(content.String(), "\n"), "\n")
+	if height := s.Height(); len(lines) > height {
+		lines = lines[:height]
+	}
+
+	var out strings.Builder
+	out.WriteString(ansi.CursorHome)
+	for i, line := range lines {
+		out.WriteString(line + ansi.ClearLine)
+		if i < len(lines)-1 {
+			out.WriteString("\n")
+		}
+	}
+	out.WriteString(ansi.ClearToEndOfScreen)
+
+	s.write(out.String())
+}
+
+func (s *Screen) exit() {
+	if !s.active {
+		return
+	}
+
+	s.active = false
+	s.untrack()
+	s.write(ansi.ClearScreenAndHome() + ansi.ShowCursor + ansi.ExitAltScreen)
+}
+
+func (s *Screen) write(out string) {
+	_, _ = io.WriteString(s.output, s.env.ApplyProfile(out))
+}
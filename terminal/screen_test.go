@@ -0,0 +1,140 @@
+package terminal_test
+
+import (
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/pkg/errors"
+	. "github.com/pseudomuto/gooey/terminal"
+	"github.com/pseudomuto/gooey/termtest"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestScreen(vt *termtest.Terminal) *Screen {
+	return NewScreen(
+		WithOutput(vt),
+		WithEnvironment(Environment{Width: 20, Height: 3}),
+	)
+}
+
+func TestScreenEnterAndExit(t *testing.T) {
+	vt := termtest.New(20, termtest.WithHeight(3))
+	fmt.Fprint(vt, "$ status-board")
+
+	scr := newTestScreen(vt)
+	require.False(t, scr.Active())
+
+	scr.Enter()
+	require.True(t, scr.Active())
+	require.True(t, vt.AltScreen())
+	require.False(t, vt.CursorVisible())
+
+	scr.Render(func(w io.Writer) {
+		fmt.Fprintln(w, "api: healthy")
+		fmt.Fprintln(w, "web: degraded")
+	})
+	require.Equal(t, "api: healthy\nweb: degraded\n", vt.Screen().String())
+
+	scr.Exit()
+	require.False(t, scr.Active())
+	require.False(t, vt.AltScreen())
+	require.True(t, vt.CursorVisible())
+	require.Equal(t, "$ status-board\n", vt.Screen().String())
+
+	// Exiting again is a no-op
+	raw := vt.Raw()
+	scr.Exit()
+	require.Equal(t, raw, vt.Raw())
+}
+
+func TestScreenRenderReplacesPreviousContent(t *testing.T) {
+	vt := termtest.New(20, termtest.WithHeight(3))
+	scr := newTestScreen(vt)
+	scr.Enter()
+	defer scr.Exit()
+
+	scr.Render(func(w io.Writer) {
+		fmt.Fprintln(w, "first render line")
+		fmt.Fprintln(w, "second line")
+		fmt.Fprintln(w, "third line")
+	})
+
+	scr.Render(func(w io.Writer) {
+		fmt.Fprintln(w, "short")
+	})
+
+	require.Equal(t, "short\n", vt.Screen().String())
+}
+
+func TestScreenRenderClipsToHeight(t *testing.T) {
+	vt := termtest.New(20, termtest.WithHeight(3))
+	scr := newTestScreen(vt)
+	scr.Enter()
+	defer scr.Exit()
+
+	scr.Render(func(w io.Writer) {
+		for i := range 5 {
+			fmt.Fprintf(w, "line %d\n", i+1)
+		}
+	})
+
+	require.Equal(t, "line 1\nline 2\nline 3\n", vt.Screen().String())
+}
+
+func TestScreenRenderWhenInactive(t *testing.T) {
+	vt := termtest.New(20)
+	scr := newTestScreen(vt)
+
+	scr.Render(func(w io.Writer) {
+		fmt.Fprintln(w, "ignored")
+	})
+
+	require.Empty(t, vt.Raw())
+}
+
+func TestScreenRun(t *testing.T) {
+	vt := termtest.New(20, termtest.WithHeight(3))
+	scr := newTestScreen(vt)
+
+	err := scr.Run(func(s *Screen) error {
+		require.True(t, vt.AltScreen())
+		return errors.New("boom")
+	})
+
+	require.EqualError(t, err, "boom")
+	require.False(t, vt.AltScreen())
+	require.True(t, vt.CursorVisible())
+}
+
+func TestScreenRunRestoresAfterPanic(t *testing.T) {
+	vt := termtest.New(20, termtest.WithHeight(3))
+	scr := newTestScreen(vt)
+
+	require.PanicsWithValue(t, "boom", func() {
+		_ = scr.Run(func(s *Screen) error {
+			panic("boom")
+		})
+	})
+
+	require.False(t, scr.Active())
+	require.False(t, vt.AltScreen())
+	require.True(t, vt.CursorVisible())
+}
+
+func TestScreenDimensions(t *testing.T) {
+	scr := NewScreen(WithEnvironment(Environment{Width: 100, Height: 40}))
+	require.Equal(t, 100, scr.Width())
+	require.Equal(t, 40, scr.Height())
+}
+
+func TestScreenRestoredOnInterrupt(t *testing.T) {
+	vt := termtest.New(20, termtest.WithHeight(3))
+	scr := newTestScreen(vt)
+	scr.Enter()
+
+	Interrupt()
+	require.False(t, scr.Active())
+	require.False(t, vt.AltScreen())
+	require.True(t, vt.CursorVisible())
+}
@@ -0,0 +1,192 @@
+// Package terminal describes the terminal environment that gooey components render into.
+// By default the width, TTY status and colour support are detected from the process, but they can be
+// overridden globally or per component so that CLIs built on gooey render deterministically in tests.
+package terminal
+
+import (
+	"os"
+	"sync"
+
+	"github.com/pseudomuto/gooey/ansi"
+	"github.com/pseudomuto/gooey/ci"
+	"github.com/pseudomuto/gooey/internal/term"
+)
+
+const (
+	// TTYAuto detects whether stdout is a terminal
+	TTYAuto TTYMode = iota
+	// TTYOn forces TTY behaviour such as in-place line updates
+	TTYOn
+	// TTYOff forces non-TTY behaviour where updates are appended as new lines
+	TTYOff
+)
+
+const (
+	// ColorAuto enables colour unless the NO_COLOR environment variable is set
+	ColorAuto ColorProfile = iota
+	// ColorANSI always emits ANSI colour and style sequences
+	ColorANSI
+	// ColorNone strips all colour and style sequences from the output
+	ColorNone
+)
+
+var (
+	defaultEnvironment      Environment
+	defaultEnvironmentMutex sync.RWMutex
+)
+
+type (
+	// TTYMode controls whether components treat their output as an interactive terminal.
+	TTYMode int
+
+	// ColorProfile controls whether components emit colour and style sequences.
+	ColorProfile int
+
+	// Environment describes the terminal that components render into. The zero value detects everything
+	// from the running process.
+	//
+	// Example:
+	//
+	//	// Render an 80 column, colourless, interactive terminal regardless of where tests run
+	//	env := terminal.Environment{Width: 80, TTY: terminal.TTYOn, Color: terminal.ColorNone}
+	//	f := frame.Open("Build", frame.WithTerminal(env), frame.WithOutput(&buf))
+	Environment struct {
+		// Width is the terminal width in columns. Zero or less detects the width from the terminal.
+		Width int
+		// Height is the terminal height in rows. Zero or less detects the height from the terminal.
+		Height int
+		// TTY controls whether the output is treated as an interactive terminal.
+		TTY TTYMode
+		// Color controls whether colour and style sequences are emitted.
+		Color ColorProfile
+		// CI is the continuous integration provider the output is logged to, which frames use to fold
+		// their content and animated components use to print lines instead of redrawing them. Nil detects
+		// the provider from the process's environment variables; ci.None means the output isn't a CI log.
+		CI ci.Provider
+	}
+)
+
+// Default returns the environment used by components that were not given one explicitly.
+func Default() Environment {
+	defaultEnvironmentMutex.RLock()
+	defer defaultEnvironmentMutex.RUnlock()
+	return defaultEnvironment
+}
+
+// SetDefault replaces the environment used by components that were not given one explicitly and returns
+// a function that restores the previous environment. Components capture the default when they are
+// created, so this should be called before creating them.
+//
+// Example:
+//
+//	func TestMain(m *testing.M) {
+//		terminal.SetDefault(terminal.Environment{Width: 80, TTY: terminal.TTYOff})
+//		os.Exit(m.Run())
+//	}
+func SetDefault(env Environment) (restore func()) {
+	defaultEnvironmentMutex.Lock()
+	previous := defaultEnvironment
+	defaultEnvironment = env
+	defaultEnvironmentMutex.Unlock()
+
+	return func() {
+		defaultEnvironmentMutex.Lock()
+		defaultEnvironment = previous
+		defaultEnvironmentMutex.Unlock()
+	}
+}
+
+// Detect returns a copy of the environment with the width, height, TTY status and CI provider that would
+// be detected filled in, so they no longer change if the process's output is redirected later on.
+//
+// Example:
+//
+//	env := terminal.Default().Detect()
+//	fmt.Println(env.Width, env.IsTTY()) // e.g. 120 true
+func (e Environment) Detect() Environment {
+	e.Width = e.Columns()
+	e.Height = e.Rows()
+	if e.IsTTY() {
+		e.TTY = TTYOn
+	} else {
+		e.TTY = TTYOff
+	}
+
+	if e.CI = e.CIProvider(); e.CI == nil {
+		e.CI = ci.None
+	}
+
+	return e
+}
+
+// CIProvider returns the CI provider the output is logged to, detecting it from the process's
+// environment variables when none is set. It returns nil when the output isn't a CI log.
+//
+// Example:
+//
+//	if p := terminal.Default().CIProvider(); p != nil {
+//		fmt.Println("folding frames for", p.Name())
+//	}
+func (e Environment) CIProvider() ci.Provider {
+	switch e.CI {
+	case nil:
+		return ci.Detect(os.Getenv)
+	case ci.None:
+		return nil
+	default:
+		return e.CI
+	}
+}
+
+// Columns returns the configured width, detecting it from the terminal when no width is set.
+func (e Environment) Columns() int {
+	if e.Width > 0 {
+		return e.Width
+	}
+
+	return term.Width()
+}
+
+// Rows returns the configured height, detecting it from the terminal when no height is set.
+func (e Environment) Rows() int {
+	if e.Height > 0 {
+		return e.Height
+	}
+
+	return term.Height()
+}
+
+// IsTTY reports whether the output should be treated as an interactive terminal that supports
+// cursor movement.
+func (e Environment) IsTTY() bool {
+	switch e.TTY {
+	case TTYOn:
+		return true
+	case TTYOff:
+		return false
+	default:
+		return term.IsTTY()
+	}
+}
+
+// ColorEnabled reports whether colour and style sequences should be emitted.
+func (e Environment) ColorEnabled() bool {
+	switch e.Color {
+	case ColorANSI:
+		return true
+	case ColorNone:
+		return false
+	default:
+		return os.Getenv("NO_COLOR") == ""
+	}
+}
+
+// ApplyProfile returns s unchanged when colour is enabled, and with every colour and style sequence
+// removed otherwise. Cursor movement and other control sequences are always preserved.
+func (e Environment) ApplyProfile(s string) string {
+	if e.ColorEnabled() {
+		return s
+	}
+
+	return ansi.StripStyles(s)
+}
@@ -0,0 +1,89 @@
+package terminal_test
+
+import (
+	"testing"
+
+	"github.com/pseudomuto/gooey/ansi"
+	"github.com/pseudomuto/gooey/ci"
+	"github.com/pseudomuto/gooey/internal/term"
+	. "github.com/pseudomuto/gooey/terminal"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnvironmentColumns(t *testing.T) {
+	require.Equal(t, 80, Environment{Width: 80}.Columns())
+	require.Equal(t, term.Width(), Environment{}.Columns())
+	require.Equal(t, term.Width(), Environment{Width: -1}.Columns())
+}
+
+func TestEnvironmentIsTTY(t *testing.T) {
+	require.True(t, Environment{TTY: TTYOn}.IsTTY())
+	require.False(t, Environment{TTY: TTYOff}.IsTTY())
+	require.Equal(t, term.IsTTY(), Environment{TTY: TTYAuto}.IsTTY())
+}
+
+func TestEnvironmentDetect(t *testing.T) {
+	tty := TTYOff
+	if term.IsTTY() {
+		tty = TTYOn
+	}
+
+	require.Equal(t,
+		Environment{Width: term.Width(), Height: term.Height(), TTY: tty, Color: ColorNone, CI: ci.None},
+		Environment{Color: ColorNone, CI: ci.None}.Detect(),
+	)
+
+	env := Environment{Width: 42, Height: 10, TTY: TTYOn, Color: ColorANSI, CI: ci.GitLab}
+	require.Equal(t, env, env.Detect())
+
+	t.Setenv("GITHUB_ACTIONS", "true")
+	require.Equal(t, ci.GitHubActions, Environment{}.Detect().CI)
+}
+
+func TestEnvironmentCIProvider(t *testing.T) {
+	t.Setenv("GITHUB_ACTIONS", "")
+	t.Setenv("GITLAB_CI", "")
+	t.Setenv("BUILDKITE", "")
+	require.Nil(t, Environment{}.CIProvider())
+
+	t.Setenv("GITHUB_ACTIONS", "true")
+	require.Equal(t, ci.GitHubActions, Environment{}.CIProvider())
+	require.Equal(t, ci.GitLab, Environment{CI: ci.GitLab}.CIProvider())
+
+	// None turns detection off
+	require.Nil(t, Environment{CI: ci.None}.CIProvider())
+	require.Equal(t, ci.None, Environment{CI: ci.None}.Detect().CI)
+}
+
+func TestEnvironmentColorEnabled(t *testing.T) {
+	require.True(t, Environment{Color: ColorANSI}.ColorEnabled())
+	require.False(t, Environment{Color: ColorNone}.ColorEnabled())
+
+	t.Setenv("NO_COLOR", "")
+	require.True(t, Environment{}.ColorEnabled())
+
+	t.Setenv("NO_COLOR", "1")
+	require.False(t, Environment{}.ColorEnabled())
+	require.True(t, Environment{Color: ColorANSI}.ColorEnabled())
+}
+
+func TestEnvironmentApplyProfile(t *testing.T) {
+	styled := ansi.MoveCursorUp(1) + ansi.ClearLine + ansi.Combine("done", ansi.Bold, ansi.Green)
+
+	require.Equal(t, styled, Environment{Color: ColorANSI}.ApplyProfile(styled))
+	require.Equal(t,
+		ansi.MoveCursorUp(1)+ansi.ClearLine+"done",
+		Environment{Color: ColorNone}.ApplyProfile(styled),
+	)
+}
+
+func TestSetDefault(t *testing.T) {
+	require.Equal(t, Environment{}, Default())
+
+	env := Environment{Width: 42, TTY: TTYOn, Color: ColorNone}
+	restore := SetDefault(env)
+	require.Equal(t, env, Default())
+
+	restore()
+	require.Equal(t, Environment{}, Default())
+}
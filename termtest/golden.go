@@ -0,0 +1,43 @@
+package termtest
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// UpdateGoldenEnv is the environment variable that, when set to a non-empty value, causes Golden to
+// rewrite golden files with the actual output instead of comparing against them.
+const UpdateGoldenEnv = "GOOEY_UPDATE_GOLDEN"
+
+// Golden compares got against the contents of testdata/<name>.golden, relative to the package under
+// test. When the GOOEY_UPDATE_GOLDEN environment variable is set, the golden file is (re)written with
+// got instead.
+//
+// Example:
+//
+//	vt := termtest.New(40)
+//	f := frame.Open("Deploy", frame.WithOutput(vt))
+//	f.Println("done")
+//	f.Close()
+//	termtest.Golden(t, "deploy_frame", vt.Screen().String())
+//
+// Regenerate the golden files with:
+//
+//	GOOEY_UPDATE_GOLDEN=1 go test ./...
+func Golden(t testing.TB, name, got string) {
+	t.Helper()
+
+	path := filepath.Join("testdata", name+".golden")
+	if os.Getenv(UpdateGoldenEnv) != "" {
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(got), 0o600))
+		return
+	}
+
+	want, err := os.ReadFile(path) // nolint: gosec
+	require.NoError(t, err, "missing golden file; run with %s=1 to create it", UpdateGoldenEnv)
+	require.Equal(t, string(want), got)
+}
@@ -0,0 +1,121 @@
+package termtest
+
+import (
+	"strings"
+
+	"github.com/pseudomuto/gooey/ansi"
+)
+
+type (
+	// Style captures the SGR attributes that were active when a cell was written.
+	// Foreground and Background use ansi.Reset to represent the terminal default.
+	Style struct {
+		Foreground    ansi.Color
+		Background    ansi.Color
+		Bold          bool
+		Dim           bool
+		Italic        bool
+		Underline     bool
+		Blink         bool
+		Reverse       bool
+		Strikethrough bool
+	}
+
+	// Cell is a single column of the screen grid. Wide characters occupy two cells: the first holds
+	// the content with a Width of 2, and the second is a continuation cell with empty content and a
+	// Width of 0.
+	Cell struct {
+		Content string
+		Width   int
+		Style   Style
+	}
+
+	// Screen is a snapshot of the terminal's visible grid. It is safe to inspect after the terminal
+	// has received more output since it does not share memory with the Terminal that produced it.
+	Screen struct {
+		width int
+		rows  [][]Cell
+	}
+)
+
+func blankCell() Cell {
+	return Cell{Content: " ", Width: 1}
+}
+
+func blankRow(width int) []Cell {
+	row := make([]Cell, width)
+	for i := range row {
+		row[i] = blankCell()
+	}
+
+	return row
+}
+
+// Width returns the number of columns in the screen.
+func (s *Screen) Width() int {
+	return s.width
+}
+
+// Height returns the number of rows in the screen.
+func (s *Screen) Height() int {
+	return len(s.rows)
+}
+
+// Cell returns the cell at the given 0-based row and column. Coordinates outside the grid return a
+// blank cell.
+func (s *Screen) Cell(row, col int) Cell {
+	if row < 0 || row >= len(s.rows) || col < 0 || col >= s.width {
+		return blankCell()
+	}
+
+	return s.rows[row][col]
+}
+
+// Line returns the plain text of the given 0-based row with trailing spaces removed.
+func (s *Screen) Line(row int) string {
+	if row < 0 || row >= len(s.rows) {
+		return ""
+	}
+
+	var sb strings.Builder
+	for _, cell := range s.rows[row] {
+		sb.WriteString(cell.Content)
+	}
+
+	return strings.TrimRight(sb.String(), " ")
+}
+
+// Lines returns the plain text of every row with trailing spaces removed. Trailing blank rows are
+// dropped so that the result only covers what was actually drawn.
+func (s *Screen) Lines() []string {
+	lines := make([]string, len(s.rows))
+	for i := range s.rows {
+		lines[i] = s.Line(i)
+	}
+
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	return lines
+}
+
+// String returns what the user would see on the screen, without any styling. Each row is terminated
+// by a newline, trailing spaces are trimmed, and trailing blank rows are omitted. This makes the result
+// suitable for golden-file comparisons.
+//
+// Example:
+//
+//	vt := termtest.New(40)
+//	f := frame.Open("Build", frame.WithOutput(vt))
+//	f.Println("compiling...")
+//	f.Close()
+//	termtest.Golden(t, "build", vt.Screen().String())
+func (s *Screen) String() string {
+	lines := s.Lines()
+	if len(lines) == 0 {
+		return ""
+	}
+
+	return strings.Join(lines, "\n") + "\n"
+}
@@ -0,0 +1,546 @@
+// Package termtest provides an in-memory VT100 terminal for deterministic rendering tests.
+// Rather than asserting on raw byte streams full of cursor movements and SGR codes, tests can write
+// gooey's output to a Terminal and inspect the final grid of styled cells that a user would actually see.
+package termtest
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/mattn/go-runewidth"
+	"github.com/pseudomuto/gooey/ansi"
+)
+
+const (
+	defaultWidth = 80
+	tabWidth     = 8
+	escape       = 0x1b
+)
+
+type (
+	// Terminal is an in-memory VT100 screen model that implements io.Writer. It interprets the subset of
+	// control sequences that gooey emits (cursor movement, erase line/display, carriage returns, SGR
+	// styling, cursor visibility and the alternate screen) and keeps track of the resulting grid.
+	//
+	// Like a terminal with the default onlcr setting, a line feed also returns the cursor to column 0.
+	Terminal struct {
+		mutex         sync.Mutex
+		width         int
+		height        int
+		primary       [][]Cell
+		alternate     [][]Cell
+		altScreen     bool
+		row           int
+		col           int
+		savedRow      int
+		savedCol      int
+		pendingWrap   bool
+		style         Style
+		cursorVisible bool
+		pending       []byte
+		raw           bytes.Buffer
+	}
+
+	// TerminalOption is a function type for configuring terminals
+	TerminalOption func(*Terminal)
+)
+
+// New creates a new virtual terminal with the given width in columns. A width less than 1 falls back
+// to 80 columns.
+//
+// By default the terminal has no fixed height: rows are added as output requires them so the whole
+// transcript can be inspected. Use WithHeight to model a fixed-size screen that scrolls.
+//
+// Example:
+//
+//	vt := termtest.New(60)
+//	s := spinner.New("Loading...", spinner.WithOutput(vt))
+//	s.Start()
+//	s.Stop()
+//	require.Equal(t, "✓ Loading...\n", vt.Screen().String())
+func New(width int, options ...TerminalOption) *Terminal {
+	if width < 1 {
+		width = defaultWidth
+	}
+
+	t := &Terminal{
+		width:         width,
+		cursorVisible: true,
+	}
+
+	for _, option := range options {
+		option(t)
+	}
+
+	t.primary = t.newBuffer()
+	return t
+}
+
+// WithHeight gives the terminal a fixed number of rows. Output that moves past the last row scrolls
+// the screen up, discarding the top row.
+func WithHeight(height int) TerminalOption {
+	return func(t *Terminal) {
+		if height > 0 {
+			t.height = height
+		}
+	}
+}
+
+// Write implements io.Writer, interpreting the bytes as terminal output. Escape sequences and
+// multi-byte characters may be split across calls.
+func (t *Terminal) Write(p []byte) (int, error) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.raw.Write(p)
+	t.pending = append(t.pending, p...)
+	consumed := t.process(t.pending)
+	t.pending = append(t.pending[:0], t.pending[consumed:]...)
+
+	return len(p), nil
+}
+
+// Screen returns a snapshot of the currently active screen buffer.
+func (t *Terminal) Screen() *Screen {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	buf := t.buffer()
+	rows := make([][]Cell, len(buf))
+	for i, row := range buf {
+		rows[i] = append([]Cell(nil), row...)
+	}
+
+	return &Screen{width: t.width, rows: rows}
+}
+
+// Raw returns every byte that has been written to the terminal, unmodified.
+func (t *Terminal) Raw() string {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	return t.raw.String()
+}
+
+// Cursor returns the 0-based row and column of the cursor.
+func (t *Terminal) Cursor() (row, col int) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	return t.row, t.col
+}
+
+// CursorVisible reports whether the cursor is currently shown.
+func (t *Terminal) CursorVisible() bool {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	return t.cursorVisible
+}
+
+// AltScreen reports whether the alternate screen buffer is active.
+func (t *Terminal) AltScreen() bool {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	return t.altScreen
+}
+
+func (t *Terminal) newBuffer() [][]Cell {
+	rows := max(t.height, 1)
+	buf := make([][]Cell, rows)
+	for i := range buf {
+		buf[i] = blankRow(t.width)
+	}
+
+	return buf
+}
+
+func (t *Terminal) buffer() [][]Cell {
+	if t.altScreen {
+		return t.alternate
+	}
+
+	return t.primary
+}
+
+func (t *Terminal) setBuffer(buf [][]Cell) {
+	if t.altScreen {
+		t.alternate = buf
+		return
+	}
+
+	t.primary = buf
+}
+
+// process interprets as much of p as possible and returns the number of bytes consumed. Incomplete
+// escape sequences and runes are left for the next call.
+func (t *Terminal) process(p []byte) int {
+	i := 0
+	for i < len(p) {
+		b := p[i]
+		switch {
+		case b == escape:
+			n := t.processEscape(p[i:])
+			if n == 0 {
+				return i
+			}
+			i += n
+		case b < 0x20 || b == 0x7f:
+			t.control(b)
+			i++
+		default:
+			r, size := utf8.DecodeRune(p[i:])
+			if r == utf8.RuneError && size <= 1 && !utf8.FullRune(p[i:]) {
+				return i
+			}
+			t.print(r)
+			i += size
+		}
+	}
+
+	return i
+}
+
+func (t *Terminal) control(b byte) {
+	switch b {
+	case '\n':
+		t.lineFeed()
+		t.col = 0
+	case '\r':
+		t.col = 0
+		t.pendingWrap = false
+	case '\b':
+		t.col = max(t.col-1, 0)
+		t.pendingWrap = false
+	case '\t':
+		t.col = min((t.col/tabWidth+1)*tabWidth, t.width-1)
+	}
+}
+
+// processEscape handles a single escape sequence at the start of p and returns its length, or 0 if the
+// sequence is incomplete.
+func (t *Terminal) processEscape(p []byte) int {
+	if len(p) < 2 {
+		return 0
+	}
+
+	switch p[1] {
+	case '[':
+		for i := 2; i < len(p); i++ {
+			if p[i] >= 0x40 && p[i] <= 0x7e {
+				t.csi(string(p[2:i]), p[i])
+				return i + 1
+			}
+		}
+		return 0
+	case ']':
+		for i := 2; i < len(p); i++ {
+			if p[i] == 0x07 {
+				return i + 1
+			}
+			if p[i] == escape && i+1 < len(p) && p[i+1] == '\\' {
+				return i + 2
+			}
+		}
+		return 0
+	case '7':
+		t.saveCursor()
+	case '8':
+		t.restoreCursor()
+	}
+
+	return 2
+}
+
+func (t *Terminal) csi(params string, final byte) {
+	if strings.HasPrefix(params, "?") {
+		t.privateMode(params[1:], final)
+		return
+	}
+
+	args := parseParams(params)
+	n := max(arg(args, 0, 1), 1)
+
+	switch final {
+	case 'A':
+		t.moveTo(t.row-n, t.col)
+	case 'B':
+		t.moveTo(min(t.row+n, len(t.buffer())-1), t.col)
+	case 'C':
+		t.moveTo(t.row, t.col+n)
+	case 'D':
+		t.moveTo(t.row, t.col-n)
+	case 'E':
+		t.moveTo(min(t.row+n, len(t.buffer())-1), 0)
+	case 'F':
+		t.moveTo(t.row-n, 0)
+	case 'G':
+		t.moveTo(t.row, n-1)
+	case 'H', 'f':
+		t.ensureRow(max(arg(args, 0, 1), 1) - 1)
+		t.moveTo(max(arg(args, 0, 1), 1)-1, max(arg(args, 1, 1), 1)-1)
+	case 'J':
+		t.eraseDisplay(arg(args, 0, 0))
+	case 'K':
+		t.eraseLine(arg(args, 0, 0))
+	case 'm':
+		t.sgr(args)
+	case 's':
+		t.saveCursor()
+	case 'u':
+		t.restoreCursor()
+	}
+}
+
+func (t *Terminal) privateMode(params string, final byte) {
+	if final != 'h' && final != 'l' {
+		return
+	}
+
+	enable := final == 'h'
+	for _, mode := range parseParams(params) {
+		switch mode {
+		case 25:
+			t.cursorVisible = enable
+		case 47, 1047, 1049:
+			t.switchScreen(enable, mode == 1049)
+		}
+	}
+}
+
+func (t *Terminal) switchScreen(alt, saveCursor bool) {
+	if alt == t.altScreen {
+		return
+	}
+
+	if alt {
+		if saveCursor {
+			t.saveCursor()
+		}
+		t.altScreen = true
+		t.alternate = t.newBuffer()
+		t.row, t.col = 0, 0
+		return
+	}
+
+	t.altScreen = false
+	t.alternate = nil
+	if saveCursor {
+		t.restoreCursor()
+	}
+}
+
+func (t *Terminal) saveCursor() {
+	t.savedRow, t.savedCol = t.row, t.col
+}
+
+func (t *Terminal) restoreCursor() {
+	t.ensureRow(t.savedRow)
+	t.moveTo(t.savedRow, t.savedCol)
+}
+
+func (t *Terminal) moveTo(row, col int) {
+	t.row = min(max(row, 0), len(t.buffer())-1)
+	t.col = min(max(col, 0), t.width-1)
+	t.pendingWrap = false
+}
+
+// ensureRow grows an unbounded buffer so that the given row exists.
+func (t *Terminal) ensureRow(row int) {
+	if t.height > 0 {
+		return
+	}
+
+	buf := t.buffer()
+	for len(buf) <= row {
+		buf = append(buf, blankRow(t.width))
+	}
+	t.setBuffer(buf)
+}
+
+func (t *Terminal) lineFeed() {
+	t.pendingWrap = false
+	if t.height == 0 {
+		t.ensureRow(t.row + 1)
+		t.row++
+		return
+	}
+
+	if t.row < t.height-1 {
+		t.row++
+		return
+	}
+
+	buf := t.buffer()
+	buf = append(buf[1:], blankRow(t.width))
+	t.setBuffer(buf)
+}
+
+func (t *Terminal) print(r rune) {
+	width := runewidth.RuneWidth(r)
+	buf := t.buffer()
+
+	if width == 0 {
+		// Combining characters and joiners attach to the previously written cell
+		col := t.col - 1
+		if t.pendingWrap {
+			col = t.col
+		}
+		for col > 0 && buf[t.row][col].Width == 0 {
+			col--
+		}
+		if col >= 0 {
+			buf[t.row][col].Content += string(r)
+		}
+		return
+	}
+
+	if t.pendingWrap || t.col+width > t.width {
+		t.lineFeed()
+		t.col = 0
+		buf = t.buffer()
+	}
+
+	t.clearWide(buf[t.row], t.col)
+	buf[t.row][t.col] = Cell{Content: string(r), Width: width, Style: t.style}
+	if width == 2 && t.col+1 < t.width {
+		t.clearWide(buf[t.row], t.col+1)
+		buf[t.row][t.col+1] = Cell{Width: 0, Style: t.style}
+	}
+
+	if t.col+width >= t.width {
+		t.col = t.width - 1
+		t.pendingWrap = true
+		return
+	}
+
+	t.col += width
+}
+
+// clearWide blanks the other half of a wide character when one of its cells is overwritten.
+func (t *Terminal) clearWide(row []Cell, col int) {
+	switch {
+	case row[col].Width == 0 && col > 0:
+		row[col-1] = blankCell()
+	case row[col].Width == 2 && col+1 < len(row):
+		row[col+1] = blankCell()
+	}
+}
+
+func (t *Terminal) eraseLine(mode int) {
+	row := t.buffer()[t.row]
+	start, end := t.col, len(row)
+	switch mode {
+	case 1:
+		start, end = 0, t.col+1
+	case 2:
+		start = 0
+	}
+
+	for i := start; i < end; i++ {
+		row[i] = blankCell()
+	}
+}
+
+func (t *Terminal) eraseDisplay(mode int) {
+	buf := t.buffer()
+	switch mode {
+	case 0:
+		t.eraseLine(0)
+		for i := t.row + 1; i < len(buf); i++ {
+			buf[i] = blankRow(t.width)
+		}
+	case 1:
+		t.eraseLine(1)
+		for i := 0; i < t.row; i++ {
+			buf[i] = blankRow(t.width)
+		}
+	default:
+		for i := range buf {
+			buf[i] = blankRow(t.width)
+		}
+	}
+}
+
+func (t *Terminal) sgr(args []int) {
+	if len(args) == 0 {
+		t.style = Style{}
+		return
+	}
+
+	for _, code := range args {
+		switch {
+		case code == 0:
+			t.style = Style{}
+		case code == 1:
+			t.style.Bold = true
+		case code == 2:
+			t.style.Dim = true
+		case code == 3:
+			t.style.Italic = true
+		case code == 4:
+			t.style.Underline = true
+		case code == 5:
+			t.style.Blink = true
+		case code == 7:
+			t.style.Reverse = true
+		case code == 9:
+			t.style.Strikethrough = true
+		case code == 22:
+			t.style.Bold, t.style.Dim = false, false
+		case code == 23:
+			t.style.Italic = false
+		case code == 24:
+			t.style.Underline = false
+		case code == 25:
+			t.style.Blink = false
+		case code == 27:
+			t.style.Reverse = false
+		case code == 29:
+			t.style.Strikethrough = false
+		case code >= 30 && code <= 37:
+			t.style.Foreground = ansi.Black + ansi.Color(code-30)
+		case code == 39:
+			t.style.Foreground = ansi.Reset
+		case code >= 40 && code <= 47:
+			t.style.Background = ansi.Black + ansi.Color(code-40)
+		case code == 49:
+			t.style.Background = ansi.Reset
+		case code >= 90 && code <= 97:
+			t.style.Foreground = ansi.BrightBlack + ansi.Color(code-90)
+		case code >= 100 && code <= 107:
+			t.style.Background = ansi.BrightBlack + ansi.Color(code-100)
+		}
+	}
+}
+
+func parseParams(params string) []int {
+	if params == "" {
+		return nil
+	}
+
+	parts := strings.Split(params, ";")
+	args := make([]int, len(parts))
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			n = -1
+		}
+		args[i] = n
+	}
+
+	return args
+}
+
+// arg returns the parameter at index i, or def when it is missing or empty.
+func arg(args []int, i, def int) int {
+	if i >= len(args) || args[i] < 0 {
+		return def
+	}
+
+	return args[i]
+}
@@ -0,0 +1,181 @@
+package termtest_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/pseudomuto/gooey/ansi"
+	. "github.com/pseudomuto/gooey/termtest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTerminalPlainText(t *testing.T) {
+	vt := New(20)
+	fmt.Fprint(vt, "hello\nworld\n")
+
+	require.Equal(t, "hello\nworld\n", vt.Screen().String())
+	row, col := vt.Cursor()
+	require.Equal(t, 2, row)
+	require.Equal(t, 0, col)
+}
+
+func TestTerminalControlSequences(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "carriage return overwrites",
+			input:    "loading\rdone",
+			expected: "doneing\n",
+		},
+		{
+			name:     "carriage return with clear line",
+			input:    "loading\r" + ansi.ClearLine + "done",
+			expected: "done\n",
+		},
+		{
+			name:     "cursor up replaces previous line",
+			input:    "one\ntwo\n" + ansi.MoveCursorUp(1) + ansi.ClearLine + "three\n",
+			expected: "one\nthree\n",
+		},
+		{
+			name:     "cursor up and down",
+			input:    "a\nb\nc\r" + ansi.MoveCursorUp(2) + ansi.ClearLine + "x" + ansi.MoveCursorDown(2) + "\rz",
+			expected: "x\nb\nz\n",
+		},
+		{
+			name:     "absolute positioning",
+			input:    ansi.MoveCursor(2, 3) + "x",
+			expected: "\n  x\n",
+		},
+		{
+			name:     "clear screen",
+			input:    "junk\nmore junk" + ansi.ClearScreenAndHome() + "fresh",
+			expected: "fresh\n",
+		},
+		{
+			name:     "save and restore cursor",
+			input:    "ab" + ansi.SaveCursor + "\nnext" + ansi.RestoreCursor + "c",
+			expected: "abc\nnext\n",
+		},
+		{
+			name:     "backspace",
+			input:    "abc\bX",
+			expected: "abX\n",
+		},
+		{
+			name:     "line wrapping",
+			input:    "0123456789abc",
+			expected: "0123456789\nabc\n",
+		},
+		{
+			name:     "exact width does not wrap early",
+			input:    "0123456789\nnext",
+			expected: "0123456789\nnext\n",
+		},
+		{
+			name:     "wide characters",
+			input:    "你好 ✓",
+			expected: "你好 ✓\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vt := New(10)
+			fmt.Fprint(vt, tt.input)
+			require.Equal(t, tt.expected, vt.Screen().String())
+		})
+	}
+}
+
+func TestTerminalSplitWrites(t *testing.T) {
+	vt := New(20)
+	input := []byte("one\n" + ansi.MoveCursorUp(1) + ansi.Red.Colorize("✓") + " two")
+
+	for _, b := range input {
+		_, err := vt.Write([]byte{b})
+		require.NoError(t, err)
+	}
+
+	require.Equal(t, "✓ two\n", vt.Screen().String())
+	require.Equal(t, ansi.Red, vt.Screen().Cell(0, 0).Style.Foreground)
+}
+
+func TestTerminalStyles(t *testing.T) {
+	vt := New(40)
+	fmt.Fprint(vt, ansi.Combine("bold", ansi.Bold, ansi.Green)+" plain "+ansi.BrightCyan.Colorize("cyan"))
+
+	screen := vt.Screen()
+	require.Equal(t, "bold plain cyan\n", screen.String())
+
+	bold := screen.Cell(0, 0)
+	require.True(t, bold.Style.Bold)
+	require.Equal(t, ansi.Green, bold.Style.Foreground)
+
+	plain := screen.Cell(0, 6)
+	require.Equal(t, Style{}, plain.Style)
+
+	cyan := screen.Cell(0, 11)
+	require.Equal(t, ansi.BrightCyan, cyan.Style.Foreground)
+	require.False(t, cyan.Style.Bold)
+}
+
+func TestTerminalCursorVisibility(t *testing.T) {
+	vt := New(10)
+	require.True(t, vt.CursorVisible())
+
+	fmt.Fprint(vt, ansi.HideCursor)
+	require.False(t, vt.CursorVisible())
+
+	fmt.Fprint(vt, ansi.ShowCursor)
+	require.True(t, vt.CursorVisible())
+}
+
+func TestTerminalAltScreen(t *testing.T) {
+	vt := New(10, WithHeight(3))
+	fmt.Fprint(vt, "main")
+
+	fmt.Fprint(vt, "\033[?1049h")
+	require.True(t, vt.AltScreen())
+	require.Empty(t, vt.Screen().String())
+
+	fmt.Fprint(vt, "board")
+	require.Equal(t, "board\n", vt.Screen().String())
+
+	fmt.Fprint(vt, "\033[?1049l")
+	require.False(t, vt.AltScreen())
+	require.Equal(t, "main\n", vt.Screen().String())
+
+	row, col := vt.Cursor()
+	require.Equal(t, 0, row)
+	require.Equal(t, 4, col)
+}
+
+func TestTerminalFixedHeightScrolls(t *testing.T) {
+	vt := New(10, WithHeight(2))
+	fmt.Fprint(vt, "one\ntwo\nthree")
+
+	screen := vt.Screen()
+	require.Equal(t, 2, screen.Height())
+	require.Equal(t, "two\nthree\n", screen.String())
+}
+
+func TestTerminalRaw(t *testing.T) {
+	vt := New(10)
+	fmt.Fprint(vt, "a\rb")
+	require.Equal(t, "a\rb", vt.Raw())
+}
+
+func TestGolden(t *testing.T) {
+	vt := New(30)
+	fmt.Fprintln(vt, "┌── Golden ──────────────────┐")
+	fmt.Fprintln(vt, "│ pending                    │")
+	fmt.Fprint(vt, ansi.MoveCursorUp(1)+ansi.ClearLine)
+	fmt.Fprintln(vt, "│ "+ansi.Green.Colorize("done")+"                       │")
+	fmt.Fprintln(vt, "└────────────────────────────┘")
+
+	Golden(t, "golden", vt.Screen().String())
+}